@@ -0,0 +1,53 @@
+package async
+
+import (
+	"errors"
+)
+
+var (
+	ErrBulkheadFull = errors.New("bulkhead is full")
+)
+
+// Bulkhead isolates work against a dependency by bounding both the number of concurrent executions
+// and the number of executions waiting for a free slot. Unlike a queue it does not buffer indefinitely,
+// work is rejected with ErrBulkheadFull once both limits are saturated.
+type Bulkhead struct {
+	admitted chan struct{}
+	running  chan struct{}
+}
+
+// NewBulkhead returns a Bulkhead running at most maxConcurrent executions,
+// with at most maxQueue executions waiting for a free slot.
+// maxConcurrent below one is treated as one, and negative maxQueue as zero.
+func NewBulkhead(maxConcurrent, maxQueue int) *Bulkhead {
+	if maxConcurrent < 1 {
+		maxConcurrent = 1
+	}
+	if maxQueue < 0 {
+		maxQueue = 0
+	}
+	return &Bulkhead{
+		admitted: make(chan struct{}, maxConcurrent+maxQueue),
+		running:  make(chan struct{}, maxConcurrent),
+	}
+}
+
+// RunBulkhead runs fn within bulkhead, waiting for a free slot if needed.
+// If bulkhead is saturated it returns ErrBulkheadFull immediately without calling fn.
+// Panics in fn are recovered and returned as errors.
+func RunBulkhead[T any](bulkhead *Bulkhead, fn func() (T, error)) (result T, err error) {
+	select {
+	case bulkhead.admitted <- struct{}{}:
+	default:
+		err = ErrBulkheadFull
+		return
+	}
+	defer func() { <-bulkhead.admitted }()
+	bulkhead.running <- struct{}{}
+	defer func() { <-bulkhead.running }()
+	err = callSafe(func() (ferr error) {
+		result, ferr = fn()
+		return
+	})
+	return
+}
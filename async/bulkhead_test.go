@@ -0,0 +1,102 @@
+package async_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/skatiyar/goutils/async"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunBulkhead(t *testing.T) {
+	t.Run("should return value of function", func(nt *testing.T) {
+		bulkhead := async.NewBulkhead(2, 1)
+		result, resultErr := async.RunBulkhead(bulkhead, func() (string, error) {
+			return "Hello", nil
+		})
+		assert.NoError(nt, resultErr)
+		assert.Equal(nt, result, "Hello")
+	})
+	t.Run("should return error of function", func(nt *testing.T) {
+		bulkhead := async.NewBulkhead(2, 1)
+		_, resultErr := async.RunBulkhead(bulkhead, func() (string, error) {
+			return "", errors.New("an error")
+		})
+		assert.EqualError(nt, resultErr, "an error")
+	})
+	t.Run("should return panics as errors", func(nt *testing.T) {
+		bulkhead := async.NewBulkhead(2, 1)
+		_, resultErr := async.RunBulkhead(bulkhead, func() (string, error) {
+			panic("an error")
+		})
+		assert.EqualError(nt, resultErr, "panic in function: an error")
+	})
+	t.Run("should reject work when bulkhead is saturated", func(nt *testing.T) {
+		bulkhead := async.NewBulkhead(2, 1)
+		release := make(chan struct{})
+		started := make(chan struct{}, 3)
+		wg := sync.WaitGroup{}
+		errs := make([]error, 3)
+		for idx := 0; idx < 3; idx++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				_, errs[i] = async.RunBulkhead(bulkhead, func() (int, error) {
+					started <- struct{}{}
+					<-release
+					return i, nil
+				})
+			}(idx)
+		}
+		<-started
+		<-started
+		time.Sleep(50 * time.Millisecond)
+		_, rejectedErr := async.RunBulkhead(bulkhead, func() (int, error) {
+			return 0, nil
+		})
+		assert.ErrorIs(nt, rejectedErr, async.ErrBulkheadFull)
+		close(release)
+		wg.Wait()
+		assert.Equal(nt, errs, []error{nil, nil, nil})
+		_, resultErr := async.RunBulkhead(bulkhead, func() (int, error) {
+			return 0, nil
+		})
+		assert.NoError(nt, resultErr)
+	})
+	t.Run("should clamp zero and negative sizes", func(nt *testing.T) {
+		for _, sizes := range [][2]int{{0, 0}, {-1, -1}, {0, 2}, {2, -3}} {
+			bulkhead := async.NewBulkhead(sizes[0], sizes[1])
+			done := make(chan error, 1)
+			go func() {
+				_, resultErr := async.RunBulkhead(bulkhead, func() (int, error) {
+					return 1, nil
+				})
+				done <- resultErr
+			}()
+			select {
+			case resultErr := <-done:
+				assert.NoError(nt, resultErr)
+			case <-time.After(time.Second):
+				nt.Fatalf("bulkhead with sizes %v blocked", sizes)
+			}
+		}
+	})
+	t.Run("should reject concurrent call with zero sizes", func(nt *testing.T) {
+		bulkhead := async.NewBulkhead(0, 0)
+		release := make(chan struct{})
+		started := make(chan struct{})
+		go async.RunBulkhead(bulkhead, func() (int, error) {
+			close(started)
+			<-release
+			return 1, nil
+		})
+		<-started
+		_, resultErr := async.RunBulkhead(bulkhead, func() (int, error) {
+			return 2, nil
+		})
+		assert.ErrorIs(nt, resultErr, async.ErrBulkheadFull)
+		close(release)
+	})
+}
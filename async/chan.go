@@ -0,0 +1,102 @@
+package async
+
+import (
+	"context"
+)
+
+// SliceToChan returns a channel emitting each element of collection in order, closed after the last element.
+// The channel is buffered to hold the whole collection, so no go routine is left blocked if it isn't fully consumed.
+func SliceToChan[T any](collection []T) <-chan T {
+	result := make(chan T, len(collection))
+	for idx := range collection {
+		result <- collection[idx]
+	}
+	close(result)
+	return result
+}
+
+// ChanToSlice receives from ch until it is closed and returns the received values in order.
+func ChanToSlice[T any](ch <-chan T) []T {
+	result := make([]T, 0)
+	for value := range ch {
+		result = append(result, value)
+	}
+	return result
+}
+
+// SomeChan receives from ch until a value passes truth test, and returns true.
+// If ch is closed before any value passes, it returns false. On the first error or panic it returns immediately
+// with the error. Values left in ch after returning early are not consumed.
+func SomeChan[T any](ch <-chan T, fn func(value T) (bool, error)) (bool, error) {
+	for value := range ch {
+		var test bool
+		if err := callSafe(func() (ferr error) {
+			test, ferr = fn(value)
+			return
+		}); err != nil {
+			return false, err
+		} else if test {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// EveryChan receives from ch until it is closed, and returns true if every value passes truth test.
+// It returns false immediately on the first value failing the test, and on the first error or panic
+// it returns immediately with the error. Values left in ch after returning early are not consumed.
+func EveryChan[T any](ch <-chan T, fn func(value T) (bool, error)) (bool, error) {
+	for value := range ch {
+		var test bool
+		if err := callSafe(func() (ferr error) {
+			test, ferr = fn(value)
+			return
+		}); err != nil {
+			return false, err
+		} else if !test {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// Produce repeatedly calls gen in a new go routine and emits the produced values on the returned values channel.
+// gen returns a value, whether the value is valid and more may follow, and an error.
+// Production stops when gen reports no more values, returns an error or panics, or ctx is done.
+// The error, or ctx.Err() on cancellation, is sent on the returned errors channel. Both channels are closed
+// once production stops, and the values channel is unbuffered so gen is not called ahead of consumers.
+func Produce[T any](ctx context.Context, gen func() (T, bool, error)) (<-chan T, <-chan error) {
+	values := make(chan T)
+	errs := make(chan error, 1)
+	go func() {
+		defer close(errs)
+		defer close(values)
+		for {
+			select {
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			default:
+			}
+			var value T
+			var hasMore bool
+			if err := callSafe(func() (ferr error) {
+				value, hasMore, ferr = gen()
+				return
+			}); err != nil {
+				errs <- err
+				return
+			}
+			if !hasMore {
+				return
+			}
+			select {
+			case values <- value:
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			}
+		}
+	}()
+	return values, errs
+}
@@ -0,0 +1,132 @@
+package async_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/skatiyar/goutils/async"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSliceToChan(t *testing.T) {
+	t.Run("should emit values in order and close channel", func(nt *testing.T) {
+		collection := []int{2, 7, 8, 9, 1, 3}
+		ch := async.SliceToChan(collection)
+		for _, expected := range collection {
+			assert.Equal(nt, <-ch, expected)
+		}
+		_, ok := <-ch
+		assert.False(nt, ok)
+	})
+	t.Run("should return closed channel for empty collection", func(nt *testing.T) {
+		_, ok := <-async.SliceToChan([]int{})
+		assert.False(nt, ok)
+	})
+}
+
+func TestChanToSlice(t *testing.T) {
+	t.Run("should round-trip slice through channel", func(nt *testing.T) {
+		collection := []string{"the brown", "fox", "jumps over the", "brown fence"}
+		assert.Equal(nt, async.ChanToSlice(async.SliceToChan(collection)), collection)
+	})
+	t.Run("should collect values sent by another go routine", func(nt *testing.T) {
+		ch := make(chan int)
+		go func() {
+			defer close(ch)
+			for idx := 0; idx < 5; idx++ {
+				ch <- idx
+			}
+		}()
+		assert.Equal(nt, async.ChanToSlice(ch), []int{0, 1, 2, 3, 4})
+	})
+}
+
+func TestSomeChan(t *testing.T) {
+	t.Run("should return true when a value matches", func(nt *testing.T) {
+		ch := async.SliceToChan([]int{1, 3, 8, 5})
+		result, resultErr := async.SomeChan(ch, func(val int) (bool, error) {
+			return val%2 == 0, nil
+		})
+		assert.NoError(nt, resultErr)
+		assert.True(nt, result)
+		assert.Equal(nt, <-ch, 5)
+	})
+	t.Run("should return false when no value matches", func(nt *testing.T) {
+		result, resultErr := async.SomeChan(async.SliceToChan([]int{1, 3, 5}), func(val int) (bool, error) {
+			return val%2 == 0, nil
+		})
+		assert.NoError(nt, resultErr)
+		assert.False(nt, result)
+	})
+	t.Run("should return error when iterator returns error", func(nt *testing.T) {
+		result, resultErr := async.SomeChan(async.SliceToChan([]int{1, 3, 8}), func(val int) (bool, error) {
+			return true, errors.New("an error")
+		})
+		assert.Error(nt, resultErr)
+		assert.False(nt, result)
+	})
+}
+
+func TestEveryChan(t *testing.T) {
+	t.Run("should return true when every value matches", func(nt *testing.T) {
+		result, resultErr := async.EveryChan(async.SliceToChan([]int{2, 4, 6}), func(val int) (bool, error) {
+			return val%2 == 0, nil
+		})
+		assert.NoError(nt, resultErr)
+		assert.True(nt, result)
+	})
+	t.Run("should return false when a value does not match", func(nt *testing.T) {
+		ch := async.SliceToChan([]int{2, 3, 6})
+		result, resultErr := async.EveryChan(ch, func(val int) (bool, error) {
+			return val%2 == 0, nil
+		})
+		assert.NoError(nt, resultErr)
+		assert.False(nt, result)
+		assert.Equal(nt, <-ch, 6)
+	})
+	t.Run("should return error when iterator panics", func(nt *testing.T) {
+		result, resultErr := async.EveryChan(async.SliceToChan([]int{2, 4}), func(val int) (bool, error) {
+			panic("an error")
+		})
+		assert.EqualError(nt, resultErr, "panic in function: an error")
+		assert.False(nt, result)
+	})
+}
+
+func TestProduce(t *testing.T) {
+	t.Run("should emit finite sequence and close channels", func(nt *testing.T) {
+		next := 0
+		values, errs := async.Produce(context.Background(), func() (int, bool, error) {
+			if next >= 5 {
+				return 0, false, nil
+			}
+			next += 1
+			return next, true, nil
+		})
+		assert.Equal(nt, async.ChanToSlice(values), []int{1, 2, 3, 4, 5})
+		assert.NoError(nt, <-errs)
+	})
+	t.Run("should stop and return error when generator fails midway", func(nt *testing.T) {
+		next := 0
+		values, errs := async.Produce(context.Background(), func() (int, bool, error) {
+			if next >= 3 {
+				return 0, true, errors.New("an error")
+			}
+			next += 1
+			return next, true, nil
+		})
+		assert.Equal(nt, async.ChanToSlice(values), []int{1, 2, 3})
+		assert.EqualError(nt, <-errs, "an error")
+	})
+	t.Run("should stop when context is cancelled", func(nt *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		values, errs := async.Produce(ctx, func() (int, bool, error) {
+			return 1, true, nil
+		})
+		assert.Equal(nt, <-values, 1)
+		cancel()
+		async.ChanToSlice(values)
+		assert.ErrorIs(nt, <-errs, context.Canceled)
+	})
+}
@@ -1,9 +1,22 @@
 package async
 
 import (
+	"sort"
 	"sync"
+
+	"github.com/skatiyar/goutils"
+	"github.com/skatiyar/goutils/constraints"
 )
 
+// mapEntries returns entries of collection as a slice, in unspecified order.
+func mapEntries[A comparable, B any](collection map[A]B) []goutils.Entry[A, B] {
+	entries := make([]goutils.Entry[A, B], 0, len(collection))
+	for key, value := range collection {
+		entries = append(entries, goutils.Entry[A, B]{Key: key, Value: value})
+	}
+	return entries
+}
+
 func EachMap[A comparable, B any](collection map[A]B, fn func(key A, value B)) {
 	wg := sync.WaitGroup{}
 	for key, value := range collection {
@@ -88,3 +101,316 @@ func MapLimit[A comparable, B any, X comparable, Z any](collection map[A]B, fn f
 	}
 	return result
 }
+
+// MapCollectErrors runs fn for every entry of collection concurrently, without short-circuiting on error.
+// Successful results are collected into the returned map, while all errors, including recovered panics,
+// are returned joined as a goutils.MultiError. The error is nil only if every call succeeded.
+func MapCollectErrors[A comparable, B any, X comparable, Z any](collection map[A]B, fn func(key A, value B) (X, Z, error)) (map[X]Z, error) {
+	result := make(map[X]Z)
+	errs := make(goutils.MultiError, 0)
+	wg := sync.WaitGroup{}
+	resultChan := make(chan opresult[X, Z])
+	for key, val := range collection {
+		wg.Add(1)
+		go func(k A, v B) {
+			defer wg.Done()
+			res := opresult[X, Z]{}
+			res.Err = callSafe(func() (err error) {
+				res.Key, res.Value, err = fn(k, v)
+				return
+			})
+			resultChan <- res
+		}(key, val)
+	}
+	go func() {
+		wg.Wait()
+		close(resultChan)
+	}()
+	for resVal := range resultChan {
+		if resVal.Err != nil {
+			errs = append(errs, resVal.Err)
+		} else {
+			result[resVal.Key] = resVal.Value
+		}
+	}
+	if len(errs) > 0 {
+		return result, errs
+	}
+	return result, nil
+}
+
+// MapWithErrChan runs fn for every entry of collection concurrently, without short-circuiting on error,
+// and returns immediately. Successful results are stored in the returned map under the entry key,
+// while each error, including recovered panics, is sent on the returned channel as it occurs.
+// The channel is buffered to hold every error, and is closed once all calls finish.
+// The map must not be read until the channel is closed.
+func MapWithErrChan[A comparable, B any, Z any](collection map[A]B, fn func(key A, value B) (Z, error)) (map[A]Z, <-chan error) {
+	result := make(map[A]Z, len(collection))
+	errChan := make(chan error, len(collection))
+	mu := sync.Mutex{}
+	wg := sync.WaitGroup{}
+	for key, val := range collection {
+		wg.Add(1)
+		go func(k A, v B) {
+			defer wg.Done()
+			var value Z
+			if err := callSafe(func() (err error) {
+				value, err = fn(k, v)
+				return
+			}); err != nil {
+				errChan <- err
+			} else {
+				mu.Lock()
+				result[k] = value
+				mu.Unlock()
+			}
+		}(key, val)
+	}
+	go func() {
+		wg.Wait()
+		close(errChan)
+	}()
+	return result, errChan
+}
+
+// MapOrdered runs fn for every entry of collection concurrently and returns the results
+// as a slice ordered by ascending key. On the first error or panic pending entries are skipped,
+// and the function returns nil with the error.
+func MapOrdered[A constraints.Ordered, B any, Z any](collection map[A]B, fn func(key A, value B) (Z, error)) ([]Z, error) {
+	keys := make([]A, 0, len(collection))
+	for key := range collection {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	result := make([]Z, len(keys))
+	resultChan := make(chan opresult[int, Z], len(keys))
+	stop, closeStop := stopChannelCloser()
+	wg := sync.WaitGroup{}
+	for idx := range keys {
+		wg.Add(1)
+		go func(i int, k A, v B) {
+			defer wg.Done()
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			res := opresult[int, Z]{Key: i}
+			res.Err = callSafe(func() (err error) {
+				res.Value, err = fn(k, v)
+				return
+			})
+			if res.Err != nil {
+				closeStop()
+			}
+			resultChan <- res
+		}(idx, keys[idx], collection[keys[idx]])
+	}
+	wg.Wait()
+	close(resultChan)
+	for resVal := range resultChan {
+		if resVal.Err != nil {
+			return nil, resVal.Err
+		}
+		result[resVal.Key] = resVal.Value
+	}
+	return result, nil
+}
+
+// MapShards transforms every entry of each shard through fn, processing shards concurrently,
+// and merges the transformed entries into a single map. Shards are merged in order, so when
+// output keys collide across shards the last writer wins, that is the value from the shard with higher index.
+// On the first error or panic remaining entries are skipped, and the function returns nil with the error.
+func MapShards[A comparable, B any, X comparable, Z any](shards []map[A]B, fn func(key A, value B) (X, Z, error)) (map[X]Z, error) {
+	shardResults := make([]map[X]Z, len(shards))
+	resultChan := make(chan opresult[int, map[X]Z], len(shards))
+	stop, closeStop := stopChannelCloser()
+	wg := sync.WaitGroup{}
+	for idx := range shards {
+		wg.Add(1)
+		go func(i int, shard map[A]B) {
+			defer wg.Done()
+			res := opresult[int, map[X]Z]{Key: i, Value: make(map[X]Z)}
+			res.Err = callSafe(func() error {
+				for key, val := range shard {
+					select {
+					case <-stop:
+						return nil
+					default:
+					}
+					rk, rv, re := fn(key, val)
+					if re != nil {
+						return re
+					}
+					res.Value[rk] = rv
+				}
+				return nil
+			})
+			if res.Err != nil {
+				closeStop()
+			}
+			resultChan <- res
+		}(idx, shards[idx])
+	}
+	wg.Wait()
+	close(resultChan)
+	for resVal := range resultChan {
+		if resVal.Err != nil {
+			return nil, resVal.Err
+		}
+		shardResults[resVal.Key] = resVal.Value
+	}
+	result := make(map[X]Z)
+	for _, shardResult := range shardResults {
+		for key, val := range shardResult {
+			result[key] = val
+		}
+	}
+	return result, nil
+}
+
+// MapToChan runs fn for every entry of collection concurrently and sends each result on out as soon as it is produced.
+// out is owned by the caller and is not closed, the caller must keep receiving from it until the function returns.
+// On the first error or panic pending entries are skipped, results not yet sent are discarded,
+// and the function returns the error.
+func MapToChan[A comparable, B any, Z any](collection map[A]B, fn func(key A, value B) (Z, error), out chan<- Z) error {
+	errChan := make(chan error, len(collection))
+	stop, closeStop := stopChannelCloser()
+	wg := sync.WaitGroup{}
+	for key, val := range collection {
+		wg.Add(1)
+		go func(k A, v B) {
+			defer wg.Done()
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			var value Z
+			err := callSafe(func() (ferr error) {
+				value, ferr = fn(k, v)
+				return
+			})
+			if err != nil {
+				errChan <- err
+				closeStop()
+				return
+			}
+			select {
+			case out <- value:
+			case <-stop:
+			}
+		}(key, val)
+	}
+	wg.Wait()
+	close(errChan)
+	return <-errChan
+}
+
+// ReduceOrderedMap runs mapFn for every entry of collection concurrently, then folds the mapped values
+// with foldFn sequentially in ascending key order, so foldFn need not be commutative.
+// On the first error or panic in either function, the function returns initial with the error.
+func ReduceOrderedMap[A constraints.Ordered, B any, X any](collection map[A]B, mapFn func(key A, value B) (X, error), foldFn func(accumulator X, value X) (X, error), initial X) (X, error) {
+	mapped, mappedErr := MapOrdered(collection, mapFn)
+	if mappedErr != nil {
+		return initial, mappedErr
+	}
+	result := initial
+	for _, value := range mapped {
+		if err := callSafe(func() (ferr error) {
+			result, ferr = foldFn(result, value)
+			return
+		}); err != nil {
+			return initial, err
+		}
+	}
+	return result, nil
+}
+
+// EveryMap returns true if every entry in collection passes truth test, evaluating fn concurrently.
+// It returns false as soon as any test fails, and returns immediately on the first error or panic,
+// without waiting for pending calls which are skipped.
+func EveryMap[A comparable, B any](collection map[A]B, fn func(key A, value B) (bool, error)) (bool, error) {
+	return EveryMapLimit(collection, fn, len(collection))
+}
+
+// EveryMapLimit is same as EveryMap, but runs at most limit calls of fn at a time.
+func EveryMapLimit[A comparable, B any](collection map[A]B, fn func(key A, value B) (bool, error), limit int) (bool, error) {
+	failed, err := findTestSliceLimit(mapEntries(collection), func(entry goutils.Entry[A, B], idx int) (bool, error) {
+		return fn(entry.Key, entry.Value)
+	}, limit, false)
+	if err != nil {
+		return false, err
+	}
+	return !failed, nil
+}
+
+// FilterMap returns a new map of all the entries in collection which pass truth test, evaluating fn concurrently.
+// On the first error or panic pending entries are skipped, and the function returns nil with the error.
+func FilterMap[A comparable, B any](collection map[A]B, fn func(key A, value B) (bool, error)) (map[A]B, error) {
+	return FilterMapLimit(collection, fn, len(collection))
+}
+
+// FilterMapLimit is same as FilterMap, but runs at most limit calls of fn at a time.
+func FilterMapLimit[A comparable, B any](collection map[A]B, fn func(key A, value B) (bool, error), limit int) (map[A]B, error) {
+	return filterMapLimit(collection, fn, limit, true)
+}
+
+// RejectMap is the opposite of FilterMap. Removes entries which pass truth test, evaluating fn concurrently.
+// On the first error or panic pending entries are skipped, and the function returns nil with the error.
+func RejectMap[A comparable, B any](collection map[A]B, fn func(key A, value B) (bool, error)) (map[A]B, error) {
+	return RejectMapLimit(collection, fn, len(collection))
+}
+
+// RejectMapLimit is same as RejectMap, but runs at most limit calls of fn at a time.
+func RejectMapLimit[A comparable, B any](collection map[A]B, fn func(key A, value B) (bool, error), limit int) (map[A]B, error) {
+	return filterMapLimit(collection, fn, limit, false)
+}
+
+// filterMapLimit returns a new map of the entries in collection for which fn returns keep,
+// with at most limit calls of fn running at a time.
+func filterMapLimit[A comparable, B any](collection map[A]B, fn func(key A, value B) (bool, error), limit int, keep bool) (map[A]B, error) {
+	entries := mapEntries(collection)
+	tests, testsErr := mapSliceLimit(entries, func(entry goutils.Entry[A, B], idx int) (bool, error) {
+		return fn(entry.Key, entry.Value)
+	}, limit)
+	if testsErr != nil {
+		return nil, testsErr
+	}
+	result := make(map[A]B)
+	for idx, test := range tests {
+		if test == keep {
+			result[entries[idx].Key] = entries[idx].Value
+		}
+	}
+	return result, nil
+}
+
+// ReduceMap reduces collection into a single value using fn to return each successive step.
+// As a fold depends on the previous step, calls run sequentially in unspecified map order,
+// but a panic in fn is recovered and returned as an error like the other async functions.
+// On the first error or panic the function returns the last accumulated value with the error.
+func ReduceMap[A comparable, B any, X any](collection map[A]B, fn func(accumulator X, key A, value B) (X, error), initial X) (X, error) {
+	for key, value := range collection {
+		var acc X
+		if err := callSafe(func() (ferr error) {
+			acc, ferr = fn(initial, key, value)
+			return
+		}); err != nil {
+			return initial, err
+		}
+		initial = acc
+	}
+	return initial, nil
+}
+
+// DetectMapEntry returns the key and value of an entry in collection which passes truth test,
+// evaluating fn concurrently. As maps are unordered, any matching entry may be returned when several match.
+// If no entry matches it returns zero values and false. On the first error or panic pending entries
+// are skipped, and the function returns with the error.
+func DetectMapEntry[A comparable, B any](collection map[A]B, fn func(key A, value B) (bool, error)) (A, B, bool, error) {
+	entry, _, detected, err := DetectSlice(mapEntries(collection), func(value goutils.Entry[A, B], idx int) (bool, error) {
+		return fn(value.Key, value.Value)
+	})
+	return entry.Key, entry.Value, detected, err
+}
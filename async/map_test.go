@@ -1,12 +1,16 @@
 package async_test
 
 import (
+	"errors"
 	"math/rand"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/skatiyar/goutils"
 	"github.com/skatiyar/goutils/async"
 	"github.com/stretchr/testify/assert"
 )
@@ -156,3 +160,421 @@ func TestMapLimit(t *testing.T) {
 		assert.False(nt, limitExceeded)
 	})
 }
+
+func TestMapWithErrChan(t *testing.T) {
+	t.Run("should collect results and stream every error", func(nt *testing.T) {
+		collection := map[string]int{"a": 1, "b": 2, "c": 3, "d": 4, "e": 5}
+		errEven := errors.New("even value")
+		result, errChan := async.MapWithErrChan(collection, func(key string, value int) (int, error) {
+			time.Sleep(time.Duration(rand.Intn(100)) * time.Millisecond)
+			if value%2 == 0 {
+				return 0, errEven
+			}
+			return value * 10, nil
+		})
+		errs := make([]error, 0)
+		for err := range errChan {
+			errs = append(errs, err)
+		}
+		assert.Equal(nt, errs, []error{errEven, errEven})
+		assert.Equal(nt, result, map[string]int{"a": 10, "c": 30, "e": 50})
+	})
+	t.Run("should report panics as errors", func(nt *testing.T) {
+		result, errChan := async.MapWithErrChan(map[string]int{"a": 1, "b": 2}, func(key string, value int) (int, error) {
+			if key == "b" {
+				panic("boom")
+			}
+			return value, nil
+		})
+		errs := make([]error, 0)
+		for err := range errChan {
+			errs = append(errs, err)
+		}
+		assert.Len(nt, errs, 1)
+		assert.EqualError(nt, errs[0], "panic in function: boom")
+		assert.Equal(nt, result, map[string]int{"a": 1})
+	})
+	t.Run("should close channel for empty map", func(nt *testing.T) {
+		result, errChan := async.MapWithErrChan(map[string]int{}, func(key string, value int) (int, error) {
+			return value, nil
+		})
+		_, open := <-errChan
+		assert.False(nt, open)
+		assert.Empty(nt, result)
+	})
+}
+
+func TestMapCollectErrors(t *testing.T) {
+	t.Run("should return correct values when no iterator returns error", func(nt *testing.T) {
+		collection := map[string]string{"1": "the brown", "2": "fox", "3": "jumps over the", "4": "brown fence"}
+		collectionResult := map[string]string{"1": "brown", "2": "fox", "3": "jumps over", "4": "brown fence"}
+		result, resultErr := async.MapCollectErrors(collection, func(key, val string) (string, string, error) {
+			time.Sleep(time.Duration(rand.Intn(100)) * time.Millisecond)
+			return key, strings.Trim(strings.ReplaceAll(val, "the", ""), " "), nil
+		})
+		assert.NoError(nt, resultErr)
+		assert.Equal(nt, result, collectionResult)
+	})
+	t.Run("should return partial result and joined error when several iterators fail", func(nt *testing.T) {
+		collection := map[string]string{"1": "the brown", "2": "fox", "3": "jumps over the", "4": "brown fence"}
+		collectionResult := map[string]string{"2": "fox", "4": "brown fence"}
+		errFirst, errThird := errors.New("first error"), errors.New("third error")
+		result, resultErr := async.MapCollectErrors(collection, func(key, val string) (string, string, error) {
+			switch key {
+			case "1":
+				return key, val, errFirst
+			case "3":
+				return key, val, errThird
+			}
+			return key, strings.Trim(strings.ReplaceAll(val, "the", ""), " "), nil
+		})
+		assert.Error(nt, resultErr)
+		assert.Equal(nt, result, collectionResult)
+		multiErr, ok := resultErr.(goutils.MultiError)
+		assert.True(nt, ok)
+		assert.ElementsMatch(nt, multiErr, []error{errFirst, errThird})
+	})
+	t.Run("should return panics as errors", func(nt *testing.T) {
+		collection := map[string]string{"1": "the brown", "2": "fox"}
+		result, resultErr := async.MapCollectErrors(collection, func(key, val string) (string, string, error) {
+			if key == "1" {
+				panic("an error")
+			}
+			return key, val, nil
+		})
+		assert.EqualError(nt, resultErr, "panic in function: an error")
+		assert.Equal(nt, result, map[string]string{"2": "fox"})
+	})
+}
+
+func TestMapOrdered(t *testing.T) {
+	t.Run("should return values in ascending key order", func(nt *testing.T) {
+		collection := map[int]string{4: "brown fence", 1: "the brown", 3: "jumps over the", 2: "fox"}
+		collectionResult := []string{"brown", "fox", "jumps over", "brown fence"}
+		result, resultErr := async.MapOrdered(collection, func(key int, val string) (string, error) {
+			time.Sleep(time.Duration(rand.Intn(100)) * time.Millisecond)
+			return strings.Trim(strings.ReplaceAll(val, "the", ""), " "), nil
+		})
+		assert.NoError(nt, resultErr)
+		assert.Equal(nt, result, collectionResult)
+	})
+	t.Run("should return error when iterator returns error", func(nt *testing.T) {
+		collection := map[int]string{4: "brown fence", 1: "the brown", 3: "jumps over the", 2: "fox"}
+		result, resultErr := async.MapOrdered(collection, func(key int, val string) (string, error) {
+			if key == 3 {
+				return "", errors.New("an error")
+			}
+			return val, nil
+		})
+		assert.Error(nt, resultErr)
+		assert.Nil(nt, result)
+	})
+}
+
+func TestMapShards(t *testing.T) {
+	t.Run("should return merged values with last shard winning on collisions", func(nt *testing.T) {
+		shards := []map[string]int{
+			{"a": 1, "b": 2},
+			{"c": 3, "d": 4},
+			{"e": 5, "f": 6},
+		}
+		collectionResult := map[string]string{"a": "1", "b": "2", "c": "3", "d": "4", "e": "5", "f": "6"}
+		result, resultErr := async.MapShards(shards, func(key string, val int) (string, string, error) {
+			time.Sleep(time.Duration(rand.Intn(100)) * time.Millisecond)
+			return key, strconv.Itoa(val), nil
+		})
+		assert.NoError(nt, resultErr)
+		assert.Equal(nt, result, collectionResult)
+		collided, collidedErr := async.MapShards(shards, func(key string, val int) (string, string, error) {
+			time.Sleep(time.Duration(rand.Intn(100)) * time.Millisecond)
+			if val%2 == 0 {
+				return "even", key, nil
+			}
+			return "odd", key, nil
+		})
+		assert.NoError(nt, collidedErr)
+		assert.Equal(nt, collided["even"], "f")
+		assert.Equal(nt, collided["odd"], "e")
+	})
+	t.Run("should return error when iterator returns error", func(nt *testing.T) {
+		shards := []map[string]int{{"a": 1, "b": 2}, {"c": 3}, {"d": 4}}
+		result, resultErr := async.MapShards(shards, func(key string, val int) (string, int, error) {
+			if key == "c" {
+				return key, val, errors.New("an error")
+			}
+			return key, val, nil
+		})
+		assert.Error(nt, resultErr)
+		assert.Nil(nt, result)
+	})
+}
+
+func TestMapToChan(t *testing.T) {
+	t.Run("should send all results on channel", func(nt *testing.T) {
+		collection := map[string]string{"1": "the brown", "2": "fox", "3": "jumps over the", "4": "brown fence"}
+		collectionResult := []string{"brown", "fox", "jumps over", "brown fence"}
+		out := make(chan string)
+		errChan := make(chan error, 1)
+		go func() {
+			defer close(out)
+			errChan <- async.MapToChan(collection, func(key, val string) (string, error) {
+				time.Sleep(time.Duration(rand.Intn(100)) * time.Millisecond)
+				return strings.Trim(strings.ReplaceAll(val, "the", ""), " "), nil
+			}, out)
+		}()
+		results := make([]string, 0)
+		for val := range out {
+			results = append(results, val)
+		}
+		assert.NoError(nt, <-errChan)
+		assert.ElementsMatch(nt, results, collectionResult)
+	})
+	t.Run("should return error when iterator returns error", func(nt *testing.T) {
+		collection := map[string]string{"1": "the brown", "2": "fox", "3": "jumps over the", "4": "brown fence"}
+		out := make(chan string)
+		errChan := make(chan error, 1)
+		go func() {
+			defer close(out)
+			errChan <- async.MapToChan(collection, func(key, val string) (string, error) {
+				if key == "3" {
+					return "", errors.New("an error")
+				}
+				time.Sleep(time.Duration(rand.Intn(100)) * time.Millisecond)
+				return val, nil
+			}, out)
+		}()
+		results := make([]string, 0)
+		for val := range out {
+			results = append(results, val)
+		}
+		assert.EqualError(nt, <-errChan, "an error")
+		assert.NotContains(nt, results, "jumps over the")
+	})
+}
+
+func TestReduceOrderedMap(t *testing.T) {
+	t.Run("should return same value as sequential ordered fold", func(nt *testing.T) {
+		collection := map[int]string{3: "jumps over the", 1: "the brown", 4: "brown fence", 2: "fox"}
+		sequential := ""
+		for _, key := range []int{1, 2, 3, 4} {
+			sequential = sequential + "[" + strings.ToUpper(collection[key]) + "]"
+		}
+		result, resultErr := async.ReduceOrderedMap(collection, func(key int, val string) (string, error) {
+			time.Sleep(time.Duration(rand.Intn(100)) * time.Millisecond)
+			return strings.ToUpper(val), nil
+		}, func(acc string, val string) (string, error) {
+			return acc + "[" + val + "]", nil
+		}, "")
+		assert.NoError(nt, resultErr)
+		assert.Equal(nt, result, sequential)
+	})
+	t.Run("should return error when map function returns error", func(nt *testing.T) {
+		result, resultErr := async.ReduceOrderedMap(map[int]int{1: 1, 2: 2}, func(key int, val int) (int, error) {
+			return 0, errors.New("an error")
+		}, func(acc int, val int) (int, error) {
+			return acc + val, nil
+		}, 10)
+		assert.Error(nt, resultErr)
+		assert.Equal(nt, result, 10)
+	})
+	t.Run("should return error when fold function panics", func(nt *testing.T) {
+		result, resultErr := async.ReduceOrderedMap(map[int]int{1: 1, 2: 2}, func(key int, val int) (int, error) {
+			return val, nil
+		}, func(acc int, val int) (int, error) {
+			panic("an error")
+		}, 10)
+		assert.EqualError(nt, resultErr, "panic in function: an error")
+		assert.Equal(nt, result, 10)
+	})
+}
+
+func TestEveryMap(t *testing.T) {
+	t.Run("should return true when every entry passes test", func(nt *testing.T) {
+		collection := map[string]string{"1": "the brown", "2": "fox", "3": "jumps over the", "4": "brown fence"}
+		result, resultErr := async.EveryMap(collection, func(key, val string) (bool, error) {
+			time.Sleep(time.Duration(rand.Intn(100)) * time.Millisecond)
+			return strings.ContainsAny(val, "aeiou"), nil
+		})
+		assert.NoError(nt, resultErr)
+		assert.True(nt, result)
+	})
+	t.Run("should return false without waiting for slow entries", func(nt *testing.T) {
+		collection := map[string]string{"1": "the brown", "2": "fly", "3": "jumps over the", "4": "brown fence"}
+		start := time.Now()
+		result, resultErr := async.EveryMap(collection, func(key, val string) (bool, error) {
+			if key != "2" {
+				time.Sleep(500 * time.Millisecond)
+			}
+			return strings.ContainsAny(val, "aeiou"), nil
+		})
+		assert.NoError(nt, resultErr)
+		assert.False(nt, result)
+		assert.Less(nt, time.Since(start), 400*time.Millisecond)
+	})
+	t.Run("should return error when iterator returns error", func(nt *testing.T) {
+		result, resultErr := async.EveryMap(map[string]string{"1": "fox"}, func(key, val string) (bool, error) {
+			return true, errors.New("an error")
+		})
+		assert.Error(nt, resultErr)
+		assert.False(nt, result)
+	})
+}
+
+func TestEveryMapLimit(t *testing.T) {
+	t.Run("should return correct value within limit", func(nt *testing.T) {
+		collection := map[string]string{"1": "the brown", "2": "fox", "3": "jumps over the", "4": "brown fence", "5": "fly"}
+		maxLimit := 2
+		var running, limitExceeded int32
+		result, resultErr := async.EveryMapLimit(collection, func(key, val string) (bool, error) {
+			if atomic.AddInt32(&running, 1) > int32(maxLimit) {
+				atomic.StoreInt32(&limitExceeded, 1)
+			}
+			defer atomic.AddInt32(&running, -1)
+			time.Sleep(time.Duration(rand.Intn(20)) * time.Millisecond)
+			return strings.ContainsAny(val, "aeiou"), nil
+		}, maxLimit)
+		assert.NoError(nt, resultErr)
+		assert.False(nt, result)
+		assert.Equal(nt, atomic.LoadInt32(&limitExceeded), int32(0))
+	})
+}
+
+func TestFilterMap(t *testing.T) {
+	t.Run("should return entries passing test", func(nt *testing.T) {
+		collection := map[string]string{"1": "the brown", "2": "fly", "3": "jumps over the", "4": "sky"}
+		result, resultErr := async.FilterMap(collection, func(key, val string) (bool, error) {
+			time.Sleep(time.Duration(rand.Intn(100)) * time.Millisecond)
+			return strings.ContainsAny(val, "aeiou"), nil
+		})
+		assert.NoError(nt, resultErr)
+		assert.Equal(nt, result, map[string]string{"1": "the brown", "3": "jumps over the"})
+	})
+	t.Run("should return error when iterator panics", func(nt *testing.T) {
+		result, resultErr := async.FilterMap(map[string]string{"1": "fox"}, func(key, val string) (bool, error) {
+			panic("an error")
+		})
+		assert.EqualError(nt, resultErr, "panic in function: an error")
+		assert.Nil(nt, result)
+	})
+}
+
+func TestFilterMapLimit(t *testing.T) {
+	t.Run("should return entries passing test within limit", func(nt *testing.T) {
+		collection := map[string]string{"1": "the brown", "2": "fly", "3": "jumps over the", "4": "sky"}
+		maxLimit := 2
+		var running, limitExceeded int32
+		result, resultErr := async.FilterMapLimit(collection, func(key, val string) (bool, error) {
+			if atomic.AddInt32(&running, 1) > int32(maxLimit) {
+				atomic.StoreInt32(&limitExceeded, 1)
+			}
+			defer atomic.AddInt32(&running, -1)
+			time.Sleep(time.Duration(rand.Intn(20)) * time.Millisecond)
+			return strings.ContainsAny(val, "aeiou"), nil
+		}, maxLimit)
+		assert.NoError(nt, resultErr)
+		assert.Equal(nt, result, map[string]string{"1": "the brown", "3": "jumps over the"})
+		assert.Equal(nt, atomic.LoadInt32(&limitExceeded), int32(0))
+	})
+	t.Run("should return error when iterator returns error", func(nt *testing.T) {
+		result, resultErr := async.FilterMapLimit(map[string]string{"1": "fox", "2": "fly"}, func(key, val string) (bool, error) {
+			return true, errors.New("an error")
+		}, 1)
+		assert.Error(nt, resultErr)
+		assert.Nil(nt, result)
+	})
+}
+
+func TestRejectMap(t *testing.T) {
+	t.Run("should return entries failing test", func(nt *testing.T) {
+		collection := map[string]string{"1": "the brown", "2": "fly", "3": "jumps over the", "4": "sky"}
+		result, resultErr := async.RejectMap(collection, func(key, val string) (bool, error) {
+			time.Sleep(time.Duration(rand.Intn(100)) * time.Millisecond)
+			return strings.ContainsAny(val, "aeiou"), nil
+		})
+		assert.NoError(nt, resultErr)
+		assert.Equal(nt, result, map[string]string{"2": "fly", "4": "sky"})
+	})
+	t.Run("should return error when iterator returns error", func(nt *testing.T) {
+		result, resultErr := async.RejectMap(map[string]string{"1": "fox"}, func(key, val string) (bool, error) {
+			return true, errors.New("an error")
+		})
+		assert.Error(nt, resultErr)
+		assert.Nil(nt, result)
+	})
+}
+
+func TestRejectMapLimit(t *testing.T) {
+	t.Run("should return entries failing test within limit", func(nt *testing.T) {
+		collection := map[string]string{"1": "the brown", "2": "fly", "3": "jumps over the", "4": "sky"}
+		maxLimit := 2
+		var running, limitExceeded int32
+		result, resultErr := async.RejectMapLimit(collection, func(key, val string) (bool, error) {
+			if atomic.AddInt32(&running, 1) > int32(maxLimit) {
+				atomic.StoreInt32(&limitExceeded, 1)
+			}
+			defer atomic.AddInt32(&running, -1)
+			time.Sleep(time.Duration(rand.Intn(20)) * time.Millisecond)
+			return strings.ContainsAny(val, "aeiou"), nil
+		}, maxLimit)
+		assert.NoError(nt, resultErr)
+		assert.Equal(nt, result, map[string]string{"2": "fly", "4": "sky"})
+		assert.Equal(nt, atomic.LoadInt32(&limitExceeded), int32(0))
+	})
+}
+
+func TestReduceMap(t *testing.T) {
+	t.Run("should return folded value", func(nt *testing.T) {
+		collection := map[string]int{"1": 2, "2": 7, "3": 8, "4": 9}
+		result, resultErr := async.ReduceMap(collection, func(acc int, key string, val int) (int, error) {
+			return acc + val, nil
+		}, 0)
+		assert.NoError(nt, resultErr)
+		assert.Equal(nt, result, 26)
+	})
+	t.Run("should return error when reducer returns error", func(nt *testing.T) {
+		result, resultErr := async.ReduceMap(map[string]int{"1": 2}, func(acc int, key string, val int) (int, error) {
+			return acc + val, errors.New("an error")
+		}, 0)
+		assert.EqualError(nt, resultErr, "an error")
+		assert.Equal(nt, result, 0)
+	})
+	t.Run("should return error when reducer panics", func(nt *testing.T) {
+		result, resultErr := async.ReduceMap(map[string]int{"1": 2}, func(acc int, key string, val int) (int, error) {
+			panic("an error")
+		}, 0)
+		assert.EqualError(nt, resultErr, "panic in function: an error")
+		assert.Equal(nt, result, 0)
+	})
+}
+
+func TestDetectMapEntry(t *testing.T) {
+	t.Run("should return matching key and value", func(nt *testing.T) {
+		collection := map[string]int{"one": 1, "two": 2, "three": 3, "four": 4}
+		key, value, detected, err := async.DetectMapEntry(collection, func(key string, value int) (bool, error) {
+			time.Sleep(time.Duration(rand.Intn(100)) * time.Millisecond)
+			return value%2 == 0, nil
+		})
+		assert.NoError(nt, err)
+		assert.True(nt, detected)
+		assert.Equal(nt, value%2, 0)
+		assert.Equal(nt, collection[key], value)
+	})
+	t.Run("should return false when nothing matches", func(nt *testing.T) {
+		collection := map[string]int{"one": 1, "three": 3}
+		key, value, detected, err := async.DetectMapEntry(collection, func(key string, value int) (bool, error) {
+			return value > 5, nil
+		})
+		assert.NoError(nt, err)
+		assert.False(nt, detected)
+		assert.Equal(nt, key, "")
+		assert.Equal(nt, value, 0)
+	})
+	t.Run("should return error", func(nt *testing.T) {
+		collection := map[string]int{"one": 1, "two": 2}
+		_, _, detected, err := async.DetectMapEntry(collection, func(key string, value int) (bool, error) {
+			return false, errors.New("some error")
+		})
+		assert.EqualError(nt, err, "some error")
+		assert.False(nt, detected)
+	})
+}
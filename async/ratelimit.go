@@ -0,0 +1,60 @@
+package async
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter allows at most perWindow acquisitions within any sliding window of the given duration.
+type RateLimiter struct {
+	rmu       sync.Mutex
+	perWindow int
+	window    time.Duration
+	acquired  []time.Time
+}
+
+// NewRateLimiter returns a RateLimiter allowing at most perWindow acquisitions per sliding window.
+// perWindow below one is treated as one, and a window which is not positive disables limiting,
+// as every earlier acquisition has already left the window.
+func NewRateLimiter(perWindow int, window time.Duration) *RateLimiter {
+	if perWindow < 1 {
+		perWindow = 1
+	}
+	return &RateLimiter{
+		perWindow: perWindow,
+		window:    window,
+		acquired:  make([]time.Time, 0, perWindow),
+	}
+}
+
+// Wait blocks until an acquisition is allowed within the sliding window, or ctx is done.
+// Returns the context error if ctx is done before an acquisition is allowed.
+func (rl *RateLimiter) Wait(ctx context.Context) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		rl.rmu.Lock()
+		now := time.Now()
+		expired := 0
+		for expired < len(rl.acquired) && !rl.acquired[expired].After(now.Add(-rl.window)) {
+			expired += 1
+		}
+		rl.acquired = rl.acquired[expired:]
+		if len(rl.acquired) < rl.perWindow {
+			rl.acquired = append(rl.acquired, now)
+			rl.rmu.Unlock()
+			return nil
+		}
+		wait := rl.acquired[0].Add(rl.window).Sub(now)
+		rl.rmu.Unlock()
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package async_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/skatiyar/goutils/async"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiter(t *testing.T) {
+	t.Run("should allow acquisitions up to limit without waiting", func(nt *testing.T) {
+		limiter := async.NewRateLimiter(3, 200*time.Millisecond)
+		start := time.Now()
+		for idx := 0; idx < 3; idx++ {
+			assert.NoError(nt, limiter.Wait(context.Background()))
+		}
+		assert.Less(nt, time.Since(start), 50*time.Millisecond)
+	})
+	t.Run("should block acquisitions over limit until window slides", func(nt *testing.T) {
+		limiter := async.NewRateLimiter(3, 100*time.Millisecond)
+		start := time.Now()
+		for idx := 0; idx < 7; idx++ {
+			assert.NoError(nt, limiter.Wait(context.Background()))
+		}
+		assert.GreaterOrEqual(nt, time.Since(start), 200*time.Millisecond)
+	})
+	t.Run("should limit concurrent acquisitions", func(nt *testing.T) {
+		limiter := async.NewRateLimiter(2, 100*time.Millisecond)
+		start := time.Now()
+		wg := sync.WaitGroup{}
+		for idx := 0; idx < 4; idx++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				assert.NoError(nt, limiter.Wait(context.Background()))
+			}()
+		}
+		wg.Wait()
+		assert.GreaterOrEqual(nt, time.Since(start), 100*time.Millisecond)
+	})
+	t.Run("should return context error when context is done", func(nt *testing.T) {
+		limiter := async.NewRateLimiter(1, time.Second)
+		assert.NoError(nt, limiter.Wait(context.Background()))
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		assert.ErrorIs(nt, limiter.Wait(ctx), context.DeadlineExceeded)
+	})
+	t.Run("should treat non positive limit as one", func(nt *testing.T) {
+		limiter := async.NewRateLimiter(0, time.Second)
+		assert.NoError(nt, limiter.Wait(context.Background()))
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		assert.ErrorIs(nt, limiter.Wait(ctx), context.DeadlineExceeded)
+	})
+	t.Run("should not limit with non positive window", func(nt *testing.T) {
+		limiter := async.NewRateLimiter(1, 0)
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		for idx := 0; idx < 5; idx++ {
+			assert.NoError(nt, limiter.Wait(ctx))
+		}
+	})
+}
@@ -1,7 +1,15 @@
 package async
 
 import (
+	"errors"
+	"fmt"
 	"sync"
+
+	"github.com/skatiyar/goutils"
+)
+
+var (
+	ErrInvalidChunkSize = errors.New("chunk size must be greater than zero")
 )
 
 type mapResult[A comparable, B any] struct {
@@ -9,6 +17,39 @@ type mapResult[A comparable, B any] struct {
 	Value B
 }
 
+type opresult[A any, B any] struct {
+	Key   A
+	Value B
+	Err   error
+}
+
+// callSafe calls fn, converting a panic into an error.
+// Non-error panic values are wrapped as "panic in function: <value>".
+func callSafe(fn func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if rerr, ok := r.(error); ok {
+				err = rerr
+			} else {
+				err = fmt.Errorf("panic in function: %v", r)
+			}
+		}
+	}()
+	return fn()
+}
+
+// stopChannelCloser returns a stop channel and a function closing it, which is safe to call multiple times.
+// Stop channel is used to signal pending go routines to skip their work after the first failure.
+func stopChannelCloser() (chan struct{}, func()) {
+	stop := make(chan struct{})
+	once := sync.Once{}
+	return stop, func() {
+		once.Do(func() {
+			close(stop)
+		})
+	}
+}
+
 func EachSlice[T any](collection []T, fn func(idx int, value T)) {
 	wg := sync.WaitGroup{}
 	for idx := range collection {
@@ -61,6 +102,10 @@ func Slice[T any, S any](collection []T, fn func(val T) S) []S {
 	return result
 }
 
+// SliceLimit produces a new slice by mapping each value in collection through fn,
+// with at most limit calls of fn running at a time. Results are ordered with respect to collection.
+// Scheduling is FIFO: slots are acquired in index order, so an element never starts
+// before all preceding elements have acquired a slot, regardless of how long their transforms take.
 func SliceLimit[T any, S any](collection []T, fn func(val T) S, limit int) []S {
 	result := make([]S, len(collection))
 	resultChan := make(chan mapResult[int, S])
@@ -72,13 +117,14 @@ func SliceLimit[T any, S any](collection []T, fn func(val T) S, limit int) []S {
 		guard <- struct{}{}
 		go func(i int, val T) {
 			defer wg.Done()
+			value := fn(val)
 			// Guard needs to be received before sending result to prevent deadlock.
 			// As results channel is not buffered and guard will block for loop
 			// till existing go routines are able to send on result channel
 			<-guard
 			resultChan <- mapResult[int, S]{
 				Key:   i,
-				Value: fn(val),
+				Value: value,
 			}
 		}(idx, collection[idx])
 	}
@@ -91,3 +137,566 @@ func SliceLimit[T any, S any](collection []T, fn func(val T) S, limit int) []S {
 	}
 	return result
 }
+
+// mapSliceLimit calls fn for each element of collection with at most limit calls running at a time,
+// and returns results ordered with respect to collection. On the first error or panic pending elements
+// are skipped, and the function returns nil with the error.
+func mapSliceLimit[A any, X any](collection []A, fn func(value A, idx int) (X, error), limit int) ([]X, error) {
+	result := make([]X, len(collection))
+	resultChan := make(chan opresult[int, X], len(collection))
+	stop, closeStop := stopChannelCloser()
+	wg := sync.WaitGroup{}
+	guard := make(chan struct{}, limit)
+	defer close(guard)
+	for idx := range collection {
+		select {
+		case <-stop:
+		case guard <- struct{}{}:
+			wg.Add(1)
+			go func(i int, val A) {
+				defer wg.Done()
+				defer func() { <-guard }()
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				res := opresult[int, X]{Key: i}
+				res.Err = callSafe(func() (err error) {
+					res.Value, err = fn(val, i)
+					return
+				})
+				if res.Err != nil {
+					closeStop()
+				}
+				resultChan <- res
+			}(idx, collection[idx])
+		}
+	}
+	wg.Wait()
+	close(resultChan)
+	for resVal := range resultChan {
+		if resVal.Err != nil {
+			return nil, resVal.Err
+		}
+		result[resVal.Key] = resVal.Value
+	}
+	return result, nil
+}
+
+// findTestSliceLimit evaluates fn for each element of collection with at most limit calls running at a time,
+// and returns true as soon as any call returns want. Pending elements are then skipped and the function
+// returns without waiting for running calls. On the first error or panic it returns immediately with the error.
+func findTestSliceLimit[A any](collection []A, fn func(value A, idx int) (bool, error), limit int, want bool) (bool, error) {
+	resultChan := make(chan opresult[int, bool], len(collection))
+	stop, closeStop := stopChannelCloser()
+	defer closeStop()
+	guard := make(chan struct{}, limit)
+	go func() {
+		for idx := range collection {
+			select {
+			case <-stop:
+				return
+			case guard <- struct{}{}:
+				go func(i int, val A) {
+					defer func() { <-guard }()
+					select {
+					case <-stop:
+						return
+					default:
+					}
+					res := opresult[int, bool]{Key: i}
+					res.Err = callSafe(func() (err error) {
+						res.Value, err = fn(val, i)
+						return
+					})
+					resultChan <- res
+				}(idx, collection[idx])
+			}
+		}
+	}()
+	for range collection {
+		resVal := <-resultChan
+		if resVal.Err != nil {
+			return false, resVal.Err
+		} else if resVal.Value == want {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// SliceIndexed produces a new slice by mapping each value in collection through fn concurrently.
+// fn is called with the index and value of each element, and results are ordered with respect to collection.
+func SliceIndexed[T any, S any](collection []T, fn func(idx int, val T) S) []S {
+	result := make([]S, len(collection))
+	resultChan := make(chan mapResult[int, S])
+	wg := sync.WaitGroup{}
+	for idx := range collection {
+		wg.Add(1)
+		go func(i int, val T) {
+			defer wg.Done()
+			resultChan <- mapResult[int, S]{
+				Key:   i,
+				Value: fn(i, val),
+			}
+		}(idx, collection[idx])
+	}
+	go func() {
+		wg.Wait()
+		close(resultChan)
+	}()
+	for resVal := range resultChan {
+		result[resVal.Key] = resVal.Value
+	}
+	return result
+}
+
+// SliceIndexedLimit is same as SliceIndexed, but runs at most limit calls of fn at a time.
+func SliceIndexedLimit[T any, S any](collection []T, fn func(idx int, val T) S, limit int) []S {
+	result := make([]S, len(collection))
+	resultChan := make(chan mapResult[int, S])
+	wg := sync.WaitGroup{}
+	guard := make(chan struct{}, limit)
+	defer close(guard)
+	for idx := range collection {
+		wg.Add(1)
+		guard <- struct{}{}
+		go func(i int, val T) {
+			defer wg.Done()
+			value := fn(i, val)
+			// Guard needs to be received before sending result to prevent deadlock.
+			<-guard
+			resultChan <- mapResult[int, S]{
+				Key:   i,
+				Value: value,
+			}
+		}(idx, collection[idx])
+	}
+	go func() {
+		wg.Wait()
+		close(resultChan)
+	}()
+	for resVal := range resultChan {
+		result[resVal.Key] = resVal.Value
+	}
+	return result
+}
+
+// ChunkMapSlice splits collection into chunks of chunkSize elements, the last chunk holding the remainder,
+// and calls fn for each chunk concurrently with at most limit calls running at a time.
+// Results are concatenated in chunk order. On the first error or panic pending chunks are skipped,
+// and the function returns nil with the error.
+func ChunkMapSlice[A any, X any](collection []A, chunkSize int, fn func(chunk []A) ([]X, error), limit int) ([]X, error) {
+	if chunkSize <= 0 {
+		return nil, ErrInvalidChunkSize
+	}
+	chunks := make([][]A, 0, (len(collection)+chunkSize-1)/chunkSize)
+	for start := 0; start < len(collection); start += chunkSize {
+		end := start + chunkSize
+		if end > len(collection) {
+			end = len(collection)
+		}
+		chunks = append(chunks, collection[start:end:end])
+	}
+	chunkResults := make([][]X, len(chunks))
+	resultChan := make(chan opresult[int, []X], len(chunks))
+	stop, closeStop := stopChannelCloser()
+	wg := sync.WaitGroup{}
+	guard := make(chan struct{}, limit)
+	defer close(guard)
+	for idx := range chunks {
+		wg.Add(1)
+		guard <- struct{}{}
+		go func(i int, chunk []A) {
+			defer wg.Done()
+			defer func() { <-guard }()
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			res := opresult[int, []X]{Key: i}
+			res.Err = callSafe(func() (err error) {
+				res.Value, err = fn(chunk)
+				return
+			})
+			if res.Err != nil {
+				closeStop()
+			}
+			resultChan <- res
+		}(idx, chunks[idx])
+	}
+	wg.Wait()
+	close(resultChan)
+	for resVal := range resultChan {
+		if resVal.Err != nil {
+			return nil, resVal.Err
+		}
+		chunkResults[resVal.Key] = resVal.Value
+	}
+	result := make([]X, 0)
+	for _, chunkResult := range chunkResults {
+		result = append(result, chunkResult...)
+	}
+	return result, nil
+}
+
+// TreeReduce reduces collection into a single value using a parallel binary tree,
+// combining adjacent pairs concurrently at each level, so the critical path is O(log n) levels of combine.
+// combine must be associative, as pairs are grouped differently than in a sequential reduction,
+// but it need not be commutative since operands keep their order.
+// An empty collection reduces to the zero value. On the first error or panic the function returns with the error.
+func TreeReduce[T any](collection []T, combine func(a, b T) (T, error)) (T, error) {
+	var zero T
+	if len(collection) == 0 {
+		return zero, nil
+	}
+	level := collection
+	for len(level) > 1 {
+		next := make([]T, (len(level)+1)/2)
+		resultChan := make(chan opresult[int, T], len(next))
+		stop, closeStop := stopChannelCloser()
+		wg := sync.WaitGroup{}
+		for idx := 0; idx+1 < len(level); idx += 2 {
+			wg.Add(1)
+			go func(i int, a, b T) {
+				defer wg.Done()
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				res := opresult[int, T]{Key: i}
+				res.Err = callSafe(func() (err error) {
+					res.Value, err = combine(a, b)
+					return
+				})
+				if res.Err != nil {
+					closeStop()
+				}
+				resultChan <- res
+			}(idx/2, level[idx], level[idx+1])
+		}
+		if len(level)%2 == 1 {
+			next[len(next)-1] = level[len(level)-1]
+		}
+		wg.Wait()
+		close(resultChan)
+		for resVal := range resultChan {
+			if resVal.Err != nil {
+				return zero, resVal.Err
+			}
+			next[resVal.Key] = resVal.Value
+		}
+		level = next
+	}
+	return level[0], nil
+}
+
+// ScanSlice maps each value in collection through mapFn concurrently, then computes the running accumulation
+// of combine at each index using a parallel Hillis-Steele prefix scan, so the critical path is O(log n) levels
+// of combine. The value at index i is the combination of initial with the mapped values up to and including i,
+// same as a sequential scan. combine must be associative, as operands are grouped differently than in a
+// sequential scan, but it need not be commutative since operands keep their order.
+// On the first error or panic the function returns nil with the error.
+func ScanSlice[A any, X any](collection []A, mapFn func(value A) (X, error), combine func(a, b X) (X, error), initial X) ([]X, error) {
+	level, levelErr := mapSliceLimit(collection, func(value A, idx int) (X, error) {
+		return mapFn(value)
+	}, len(collection))
+	if levelErr != nil {
+		return nil, levelErr
+	}
+	for offset := 1; offset < len(level); offset *= 2 {
+		prev := level
+		if level, levelErr = mapSliceLimit(prev, func(value X, idx int) (X, error) {
+			if idx < offset {
+				return value, nil
+			}
+			return combine(prev[idx-offset], value)
+		}, len(prev)); levelErr != nil {
+			return nil, levelErr
+		}
+	}
+	return mapSliceLimit(level, func(value X, idx int) (X, error) {
+		return combine(initial, value)
+	}, len(level))
+}
+
+// PartitionSlice splits collection into values which pass truth test and values which don't,
+// evaluating fn concurrently with at most limit calls running at a time.
+// Both partitions preserve the order of collection. On the first error or panic pending
+// elements are skipped, and the function returns nil partitions with the error.
+func PartitionSlice[A any](collection []A, fn func(value A, idx int) (bool, error), limit int) (truthy []A, falsy []A, err error) {
+	tests, testsErr := mapSliceLimit(collection, fn, limit)
+	if testsErr != nil {
+		return nil, nil, testsErr
+	}
+	truthy, falsy = make([]A, 0), make([]A, 0)
+	for idx, test := range tests {
+		if test {
+			truthy = append(truthy, collection[idx])
+		} else {
+			falsy = append(falsy, collection[idx])
+		}
+	}
+	return truthy, falsy, nil
+}
+
+// GroupThenProcessNested groups values of collection by the key returned by keyFn, then processes groups
+// concurrently with at most groupLimit groups running at a time. Within each group, members are processed
+// through memberFn concurrently with at most memberLimit calls running at a time for that group.
+// Results of a group keep the order of its members in collection. On the first error or panic pending work
+// is skipped, and the function returns nil with the error.
+func GroupThenProcessNested[A any, K comparable, X any](collection []A, keyFn func(value A, idx int) (K, error), memberFn func(key K, value A) (X, error), groupLimit int, memberLimit int) (map[K][]X, error) {
+	keys := make([]K, 0)
+	groups := make(map[K][]A)
+	for idx, value := range collection {
+		var key K
+		if err := callSafe(func() (ferr error) {
+			key, ferr = keyFn(value, idx)
+			return
+		}); err != nil {
+			return nil, err
+		}
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], value)
+	}
+	groupResults, groupErr := mapSliceLimit(keys, func(key K, idx int) ([]X, error) {
+		return mapSliceLimit(groups[key], func(value A, midx int) (X, error) {
+			return memberFn(key, value)
+		}, memberLimit)
+	}, groupLimit)
+	if groupErr != nil {
+		return nil, groupErr
+	}
+	result := make(map[K][]X, len(keys))
+	for idx, key := range keys {
+		result[key] = groupResults[idx]
+	}
+	return result, nil
+}
+
+// FilterSlice returns a new slice of all the values in collection which pass truth test,
+// evaluating fn concurrently. The result preserves the order of collection.
+// On the first error or panic pending elements are skipped, and the function returns nil with the error.
+func FilterSlice[A any](collection []A, fn func(value A, idx int) (bool, error)) ([]A, error) {
+	return FilterSliceLimit(collection, fn, len(collection))
+}
+
+// FilterSliceLimit is same as FilterSlice, but runs at most limit calls of fn at a time.
+func FilterSliceLimit[A any](collection []A, fn func(value A, idx int) (bool, error), limit int) ([]A, error) {
+	tests, testsErr := mapSliceLimit(collection, fn, limit)
+	if testsErr != nil {
+		return nil, testsErr
+	}
+	result := make([]A, 0)
+	for idx, test := range tests {
+		if test {
+			result = append(result, collection[idx])
+		}
+	}
+	return result, nil
+}
+
+// RejectSlice is the opposite of FilterSlice. Removes values which pass truth test, evaluating fn concurrently.
+// The result preserves the order of collection.
+// On the first error or panic pending elements are skipped, and the function returns nil with the error.
+func RejectSlice[A any](collection []A, fn func(value A, idx int) (bool, error)) ([]A, error) {
+	return RejectSliceLimit(collection, fn, len(collection))
+}
+
+// RejectSliceLimit is same as RejectSlice, but runs at most limit calls of fn at a time.
+func RejectSliceLimit[A any](collection []A, fn func(value A, idx int) (bool, error), limit int) ([]A, error) {
+	tests, testsErr := mapSliceLimit(collection, fn, limit)
+	if testsErr != nil {
+		return nil, testsErr
+	}
+	result := make([]A, 0)
+	for idx, test := range tests {
+		if !test {
+			result = append(result, collection[idx])
+		}
+	}
+	return result, nil
+}
+
+// EverySlice returns true if every value in collection passes truth test, evaluating fn concurrently.
+// It returns false as soon as any test fails, and returns immediately on the first error or panic,
+// without waiting for pending calls which are skipped.
+func EverySlice[A any](collection []A, fn func(value A, idx int) (bool, error)) (bool, error) {
+	return EverySliceLimit(collection, fn, len(collection))
+}
+
+// EverySliceLimit is same as EverySlice, but runs at most limit calls of fn at a time.
+func EverySliceLimit[A any](collection []A, fn func(value A, idx int) (bool, error), limit int) (bool, error) {
+	failed, err := findTestSliceLimit(collection, fn, limit, false)
+	if err != nil {
+		return false, err
+	}
+	return !failed, nil
+}
+
+// SomeSlice returns true if at least one value in collection passes truth test, evaluating fn concurrently.
+// It returns true as soon as any test passes, and returns immediately on the first error or panic,
+// without waiting for pending calls which are skipped. An empty collection returns false.
+func SomeSlice[A any](collection []A, fn func(value A, idx int) (bool, error)) (bool, error) {
+	return SomeSliceLimit(collection, fn, len(collection))
+}
+
+// SomeSliceLimit is same as SomeSlice, but runs at most limit calls of fn at a time.
+func SomeSliceLimit[A any](collection []A, fn func(value A, idx int) (bool, error), limit int) (bool, error) {
+	return findTestSliceLimit(collection, fn, limit, true)
+}
+
+// DetectSlice returns the first value in collection that passes truth test along with its index,
+// evaluating fn concurrently. First means the lowest index among matching values, regardless of which
+// test finishes first, so calls for indices above a known match are skipped but lower ones are awaited.
+// If no value matches it returns the zero value, -1 and false. On the first error or panic pending
+// elements are skipped, and the function returns with the error.
+func DetectSlice[A any](collection []A, fn func(value A, idx int) (bool, error)) (A, int, bool, error) {
+	var zero A
+	rmu := sync.Mutex{}
+	matched := -1
+	errChan := make(chan error, len(collection))
+	stop, closeStop := stopChannelCloser()
+	wg := sync.WaitGroup{}
+	for idx := range collection {
+		wg.Add(1)
+		go func(i int, val A) {
+			defer wg.Done()
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			rmu.Lock()
+			skip := matched >= 0 && matched < i
+			rmu.Unlock()
+			if skip {
+				return
+			}
+			var test bool
+			if err := callSafe(func() (ferr error) {
+				test, ferr = fn(val, i)
+				return
+			}); err != nil {
+				errChan <- err
+				closeStop()
+			} else if test {
+				rmu.Lock()
+				if matched < 0 || i < matched {
+					matched = i
+				}
+				rmu.Unlock()
+			}
+		}(idx, collection[idx])
+	}
+	wg.Wait()
+	close(errChan)
+	if err := <-errChan; err != nil {
+		return zero, -1, false, err
+	}
+	if matched < 0 {
+		return zero, -1, false, nil
+	}
+	return collection[matched], matched, true, nil
+}
+
+// ReduceSlice reduces collection into a single value using fn to return each successive step.
+// As a fold depends on the previous step, calls run sequentially in order of collection,
+// but a panic in fn is recovered and returned as an error like the other async functions.
+// On the first error or panic the function returns the last accumulated value with the error.
+func ReduceSlice[A any, X any](collection []A, fn func(accumulator X, value A, idx int) (X, error), initial X) (X, error) {
+	for idx, value := range collection {
+		var acc X
+		if err := callSafe(func() (ferr error) {
+			acc, ferr = fn(initial, value, idx)
+			return
+		}); err != nil {
+			return initial, err
+		}
+		initial = acc
+	}
+	return initial, nil
+}
+
+// GroupBySlice returns a new map, where each value is a slice of items returned by fn for the corresponding group key,
+// evaluating fn concurrently. Group keys are computed in parallel, but items within a group keep the order of collection.
+// On the first error or panic pending elements are skipped, and the function returns nil with the error.
+func GroupBySlice[A any, X comparable, Y any](collection []A, fn func(value A, idx int) (X, Y, error)) (map[X][]Y, error) {
+	return GroupBySliceLimit(collection, fn, len(collection))
+}
+
+// GroupBySliceLimit is same as GroupBySlice, but runs at most limit calls of fn at a time.
+func GroupBySliceLimit[A any, X comparable, Y any](collection []A, fn func(value A, idx int) (X, Y, error), limit int) (map[X][]Y, error) {
+	groups, groupsErr := mapSliceLimit(collection, func(value A, idx int) (res mapResult[X, Y], err error) {
+		res.Key, res.Value, err = fn(value, idx)
+		return
+	}, limit)
+	if groupsErr != nil {
+		return nil, groupsErr
+	}
+	result := make(map[X][]Y)
+	for _, group := range groups {
+		result[group.Key] = append(result[group.Key], group.Value)
+	}
+	return result, nil
+}
+
+// ConcatSlice applies fn to each value in collection concurrently, concatenating the results.
+// The result is ordered with respect to collection, regardless of the order in which calls finish.
+// On the first error or panic pending elements are skipped, and the function returns nil with the error.
+func ConcatSlice[A any, X any](collection []A, fn func(value A, idx int) ([]X, error)) ([]X, error) {
+	return ConcatSliceLimit(collection, fn, len(collection))
+}
+
+// ConcatSliceLimit is same as ConcatSlice, but runs at most limit calls of fn at a time.
+func ConcatSliceLimit[A any, X any](collection []A, fn func(value A, idx int) ([]X, error), limit int) ([]X, error) {
+	values, valuesErr := mapSliceLimit(collection, fn, limit)
+	if valuesErr != nil {
+		return nil, valuesErr
+	}
+	result := make([]X, 0)
+	for _, value := range values {
+		result = append(result, value...)
+	}
+	return result, nil
+}
+
+// SliceRetry produces a new slice by mapping each value in collection through fn concurrently,
+// with at most limit elements processed at a time. A failing element is retried independently
+// up to attempts times in total, at least once, before its last error fails the whole operation. Results are ordered
+// with respect to collection. A panic is not retried. On failure pending elements are skipped,
+// and the function returns nil with the error.
+func SliceRetry[T any, S any](collection []T, fn func(val T) (S, error), attempts int, limit int) ([]S, error) {
+	return mapSliceLimit(collection, func(value T, idx int) (res S, err error) {
+		for attempt := 0; attempt < attempts || attempt == 0; attempt += 1 {
+			if res, err = fn(value); err == nil {
+				return
+			}
+		}
+		return
+	}, limit)
+}
+
+// EachBatchSlice calls fn for consecutive batches of up to size elements of collection concurrently,
+// the last batch holding the remainder. Returns goutils.ErrInvalidBatchSize if size is not positive,
+// same as goutils.EachBatchSlice.
+// On the first error or panic pending batches are skipped, and the function returns with the error.
+func EachBatchSlice[A any](collection []A, size int, fn func(batch []A) error) error {
+	return EachBatchSliceLimit(collection, size, fn, len(collection))
+}
+
+// EachBatchSliceLimit is same as EachBatchSlice, but runs at most limit calls of fn at a time.
+func EachBatchSliceLimit[A any](collection []A, size int, fn func(batch []A) error, limit int) error {
+	if size <= 0 {
+		return goutils.ErrInvalidBatchSize
+	}
+	_, err := ChunkMapSlice(collection, size, func(chunk []A) ([]struct{}, error) {
+		return nil, fn(chunk)
+	}, limit)
+	return err
+}
@@ -1,12 +1,17 @@
 package async_test
 
 import (
+	"errors"
 	"math"
 	"math/rand"
+	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/skatiyar/goutils"
 	"github.com/skatiyar/goutils/async"
 	"github.com/stretchr/testify/assert"
 )
@@ -154,3 +159,879 @@ func TestSliceLimit(t *testing.T) {
 		assert.False(nt, limitExceeded)
 	})
 }
+
+func TestSliceLimitFairness(t *testing.T) {
+	t.Run("should return ordered values without deadlock for mixed fast and slow transforms", func(nt *testing.T) {
+		collection := make([]int, 20)
+		collectionResult := make([]int, 20)
+		for idx := range collection {
+			collection[idx] = idx
+			collectionResult[idx] = idx * idx
+		}
+		maxLimit := 3
+		var running, maxRunning int32
+		done := make(chan []int)
+		go func() {
+			done <- async.SliceLimit(collection, func(val int) int {
+				current := atomic.AddInt32(&running, 1)
+				defer atomic.AddInt32(&running, -1)
+				for {
+					observed := atomic.LoadInt32(&maxRunning)
+					if current <= observed || atomic.CompareAndSwapInt32(&maxRunning, observed, current) {
+						break
+					}
+				}
+				if val%4 == 0 {
+					time.Sleep(50 * time.Millisecond)
+				} else {
+					time.Sleep(time.Millisecond)
+				}
+				return val * val
+			}, maxLimit)
+		}()
+		select {
+		case result := <-done:
+			assert.Equal(nt, result, collectionResult)
+			assert.LessOrEqual(nt, atomic.LoadInt32(&maxRunning), int32(maxLimit))
+		case <-time.After(5 * time.Second):
+			nt.Fatal("SliceLimit deadlocked")
+		}
+	})
+}
+
+func TestSliceIndexed(t *testing.T) {
+	t.Run("should pass correct index for each value", func(nt *testing.T) {
+		collection := []string{"a", "b", "c", "d", "e", "f"}
+		collectionResult := []string{"0:a", "1:b", "2:c", "3:d", "4:e", "5:f"}
+		assert.Equal(nt, async.SliceIndexed(collection, func(idx int, val string) string {
+			time.Sleep(time.Duration(rand.Intn(100)) * time.Millisecond)
+			return strconv.Itoa(idx) + ":" + val
+		}), collectionResult)
+	})
+}
+
+func TestSliceIndexedLimit(t *testing.T) {
+	t.Run("should pass correct index for each value", func(nt *testing.T) {
+		collection := []string{"a", "b", "c", "d", "e", "f"}
+		collectionResult := []string{"0:a", "1:b", "2:c", "3:d", "4:e", "5:f"}
+		maxLimit := 2
+		var running int32
+		limitExceeded := false
+		assert.Equal(nt, async.SliceIndexedLimit(collection, func(idx int, val string) string {
+			if atomic.AddInt32(&running, 1) > int32(maxLimit) {
+				limitExceeded = true
+			}
+			defer atomic.AddInt32(&running, -1)
+			time.Sleep(time.Duration(rand.Intn(100)) * time.Millisecond)
+			return strconv.Itoa(idx) + ":" + val
+		}, maxLimit), collectionResult)
+		assert.False(nt, limitExceeded)
+	})
+}
+
+func TestChunkMapSlice(t *testing.T) {
+	t.Run("should return ordered values for async operations", func(nt *testing.T) {
+		collection := []int{2, 7, 8, 9, 1, 3, 5}
+		collectionResult := []int{4, 49, 64, 81, 1, 9, 25}
+		rmu := sync.RWMutex{}
+		chunks := make([][]int, 0)
+		result, resultErr := async.ChunkMapSlice(collection, 3, func(chunk []int) ([]int, error) {
+			time.Sleep(time.Duration(rand.Intn(100)) * time.Millisecond)
+			rmu.Lock()
+			chunks = append(chunks, chunk)
+			rmu.Unlock()
+			squares := make([]int, 0, len(chunk))
+			for _, val := range chunk {
+				squares = append(squares, int(math.Pow(float64(val), 2)))
+			}
+			return squares, nil
+		}, 2)
+		assert.NoError(nt, resultErr)
+		assert.Equal(nt, result, collectionResult)
+		assert.ElementsMatch(nt, chunks, [][]int{{2, 7, 8}, {9, 1, 3}, {5}})
+	})
+	t.Run("should return empty values for empty collection", func(nt *testing.T) {
+		result, resultErr := async.ChunkMapSlice([]int{}, 3, func(chunk []int) ([]int, error) {
+			return chunk, nil
+		}, 2)
+		assert.NoError(nt, resultErr)
+		assert.Empty(nt, result)
+	})
+	t.Run("should return error when iterator returns error", func(nt *testing.T) {
+		collection := []int{2, 7, 8, 9, 1, 3, 5}
+		result, resultErr := async.ChunkMapSlice(collection, 2, func(chunk []int) ([]int, error) {
+			if chunk[0] == 8 {
+				return nil, errors.New("an error")
+			}
+			return chunk, nil
+		}, 2)
+		assert.Error(nt, resultErr)
+		assert.Nil(nt, result)
+	})
+	t.Run("should return error when iterator panics", func(nt *testing.T) {
+		result, resultErr := async.ChunkMapSlice([]int{2, 7, 8}, 2, func(chunk []int) ([]int, error) {
+			panic("an error")
+		}, 2)
+		assert.EqualError(nt, resultErr, "panic in function: an error")
+		assert.Nil(nt, result)
+	})
+	t.Run("should return error for invalid chunk size", func(nt *testing.T) {
+		result, resultErr := async.ChunkMapSlice([]int{2, 7, 8}, 0, func(chunk []int) ([]int, error) {
+			return chunk, nil
+		}, 2)
+		assert.ErrorIs(nt, resultErr, async.ErrInvalidChunkSize)
+		assert.Nil(nt, result)
+	})
+}
+
+func TestScanSlice(t *testing.T) {
+	t.Run("should match sequential prefix sum", func(nt *testing.T) {
+		collection := make([]string, 37)
+		for idx := range collection {
+			collection[idx] = strings.Repeat("x", rand.Intn(10))
+		}
+		expected := make([]int, len(collection))
+		acc := 5
+		for idx, value := range collection {
+			acc += len(value)
+			expected[idx] = acc
+		}
+		result, resultErr := async.ScanSlice(collection, func(value string) (int, error) {
+			return len(value), nil
+		}, func(a, b int) (int, error) {
+			time.Sleep(time.Duration(rand.Intn(5)) * time.Millisecond)
+			return a + b, nil
+		}, 5)
+		assert.NoError(nt, resultErr)
+		assert.Equal(nt, result, expected)
+	})
+	t.Run("should keep operand order for non commutative combine", func(nt *testing.T) {
+		result, resultErr := async.ScanSlice([]string{"a", "b", "c", "d", "e"}, func(value string) (string, error) {
+			return value, nil
+		}, func(a, b string) (string, error) {
+			return a + b, nil
+		}, ">")
+		assert.NoError(nt, resultErr)
+		assert.Equal(nt, result, []string{">a", ">ab", ">abc", ">abcd", ">abcde"})
+	})
+	t.Run("should return empty slice for empty collection", func(nt *testing.T) {
+		result, resultErr := async.ScanSlice([]int{}, func(value int) (int, error) {
+			return value, nil
+		}, func(a, b int) (int, error) {
+			return a + b, nil
+		}, 0)
+		assert.NoError(nt, resultErr)
+		assert.Equal(nt, result, []int{})
+	})
+	t.Run("should return error", func(nt *testing.T) {
+		result, resultErr := async.ScanSlice([]int{1, 2, 3, 4}, func(value int) (int, error) {
+			return value, nil
+		}, func(a, b int) (int, error) {
+			if b == 4 {
+				return 0, errors.New("some error")
+			}
+			return a + b, nil
+		}, 0)
+		assert.EqualError(nt, resultErr, "some error")
+		assert.Nil(nt, result)
+	})
+}
+
+func TestTreeReduce(t *testing.T) {
+	t.Run("should return same sum as sequential reduction", func(nt *testing.T) {
+		collection := make([]int, 1001)
+		sum := 0
+		for idx := range collection {
+			collection[idx] = rand.Intn(1000)
+			sum += collection[idx]
+		}
+		result, resultErr := async.TreeReduce(collection, func(a, b int) (int, error) {
+			return a + b, nil
+		})
+		assert.NoError(nt, resultErr)
+		assert.Equal(nt, result, sum)
+	})
+	t.Run("should preserve operand order for non-commutative combine", func(nt *testing.T) {
+		collection := []string{"the ", "brown ", "fox ", "jumps ", "over"}
+		result, resultErr := async.TreeReduce(collection, func(a, b string) (string, error) {
+			time.Sleep(time.Duration(rand.Intn(20)) * time.Millisecond)
+			return a + b, nil
+		})
+		assert.NoError(nt, resultErr)
+		assert.Equal(nt, result, "the brown fox jumps over")
+	})
+	t.Run("should return zero value for empty collection", func(nt *testing.T) {
+		result, resultErr := async.TreeReduce([]int{}, func(a, b int) (int, error) {
+			return a + b, nil
+		})
+		assert.NoError(nt, resultErr)
+		assert.Equal(nt, result, 0)
+	})
+	t.Run("should return error when combine returns error", func(nt *testing.T) {
+		result, resultErr := async.TreeReduce([]int{2, 7, 8, 9, 1, 3}, func(a, b int) (int, error) {
+			if a == 8 {
+				return 0, errors.New("an error")
+			}
+			return a + b, nil
+		})
+		assert.Error(nt, resultErr)
+		assert.Equal(nt, result, 0)
+	})
+	t.Run("should return error when combine panics", func(nt *testing.T) {
+		_, resultErr := async.TreeReduce([]int{2, 7, 8}, func(a, b int) (int, error) {
+			panic("an error")
+		})
+		assert.EqualError(nt, resultErr, "panic in function: an error")
+	})
+}
+
+func TestPartitionSlice(t *testing.T) {
+	t.Run("should preserve order within both partitions", func(nt *testing.T) {
+		collection := []int{2, 7, 8, 9, 1, 3, 4, 6}
+		truthy, falsy, err := async.PartitionSlice(collection, func(val int, idx int) (bool, error) {
+			time.Sleep(time.Duration(rand.Intn(100)) * time.Millisecond)
+			return val%2 == 0, nil
+		}, 3)
+		assert.NoError(nt, err)
+		assert.Equal(nt, truthy, []int{2, 8, 4, 6})
+		assert.Equal(nt, falsy, []int{7, 9, 1, 3})
+	})
+	t.Run("should respect concurrency limit", func(nt *testing.T) {
+		collection := []int{2, 7, 8, 9, 1, 3, 4, 6}
+		maxLimit := 2
+		var running int32
+		var limitExceeded int32
+		_, _, err := async.PartitionSlice(collection, func(val int, idx int) (bool, error) {
+			if atomic.AddInt32(&running, 1) > int32(maxLimit) {
+				atomic.StoreInt32(&limitExceeded, 1)
+			}
+			defer atomic.AddInt32(&running, -1)
+			time.Sleep(time.Duration(rand.Intn(20)) * time.Millisecond)
+			return val%2 == 0, nil
+		}, maxLimit)
+		assert.NoError(nt, err)
+		assert.Equal(nt, atomic.LoadInt32(&limitExceeded), int32(0))
+	})
+	t.Run("should return error when iterator returns error", func(nt *testing.T) {
+		collection := []int{2, 7, 8, 9, 1, 3, 4, 6}
+		truthy, falsy, err := async.PartitionSlice(collection, func(val int, idx int) (bool, error) {
+			if idx == 3 {
+				return false, errors.New("an error")
+			}
+			return val%2 == 0, nil
+		}, 2)
+		assert.Error(nt, err)
+		assert.Nil(nt, truthy)
+		assert.Nil(nt, falsy)
+	})
+	t.Run("should return error when iterator panics", func(nt *testing.T) {
+		_, _, err := async.PartitionSlice([]int{2, 7}, func(val int, idx int) (bool, error) {
+			panic(errors.New("an error"))
+		}, 2)
+		assert.EqualError(nt, err, "an error")
+	})
+}
+
+func TestGroupThenProcessNested(t *testing.T) {
+	t.Run("should return grouped results respecting both limits", func(nt *testing.T) {
+		collection := make([]int, 0)
+		for idx := 0; idx < 40; idx++ {
+			collection = append(collection, idx)
+		}
+		groupLimit, memberLimit := 2, 3
+		rmu := sync.Mutex{}
+		running := make(map[int]int)
+		groupLimitExceeded, memberLimitExceeded := false, false
+		result, resultErr := async.GroupThenProcessNested(collection, func(val int, idx int) (int, error) {
+			return val % 5, nil
+		}, func(key int, val int) (int, error) {
+			rmu.Lock()
+			running[key] += 1
+			if running[key] > memberLimit {
+				memberLimitExceeded = true
+			}
+			activeGroups := 0
+			for _, count := range running {
+				if count > 0 {
+					activeGroups += 1
+				}
+			}
+			if activeGroups > groupLimit {
+				groupLimitExceeded = true
+			}
+			rmu.Unlock()
+			time.Sleep(time.Duration(rand.Intn(20)) * time.Millisecond)
+			rmu.Lock()
+			running[key] -= 1
+			rmu.Unlock()
+			return val * 10, nil
+		}, groupLimit, memberLimit)
+		assert.NoError(nt, resultErr)
+		assert.Len(nt, result, 5)
+		for key, values := range result {
+			expected := make([]int, 0)
+			for val := key; val < 40; val += 5 {
+				expected = append(expected, val*10)
+			}
+			assert.Equal(nt, values, expected)
+		}
+		assert.False(nt, groupLimitExceeded)
+		assert.False(nt, memberLimitExceeded)
+	})
+	t.Run("should return error when member function returns error", func(nt *testing.T) {
+		result, resultErr := async.GroupThenProcessNested([]int{1, 2, 3, 4}, func(val int, idx int) (bool, error) {
+			return val%2 == 0, nil
+		}, func(key bool, val int) (int, error) {
+			if val == 3 {
+				return 0, errors.New("an error")
+			}
+			return val, nil
+		}, 2, 2)
+		assert.Error(nt, resultErr)
+		assert.Nil(nt, result)
+	})
+	t.Run("should return error when key function returns error", func(nt *testing.T) {
+		result, resultErr := async.GroupThenProcessNested([]int{1, 2, 3, 4}, func(val int, idx int) (bool, error) {
+			return false, errors.New("an error")
+		}, func(key bool, val int) (int, error) {
+			return val, nil
+		}, 2, 2)
+		assert.Error(nt, resultErr)
+		assert.Nil(nt, result)
+	})
+}
+
+func TestFilterSlice(t *testing.T) {
+	t.Run("should return values passing test in original order", func(nt *testing.T) {
+		collection := []int{2, 7, 8, 9, 1, 3, 4, 6}
+		result, resultErr := async.FilterSlice(collection, func(val int, idx int) (bool, error) {
+			time.Sleep(time.Duration(rand.Intn(100)) * time.Millisecond)
+			return val%2 == 0, nil
+		})
+		assert.NoError(nt, resultErr)
+		assert.Equal(nt, result, []int{2, 8, 4, 6})
+	})
+	t.Run("should return empty slice for empty collection", func(nt *testing.T) {
+		result, resultErr := async.FilterSlice([]int{}, func(val int, idx int) (bool, error) {
+			return true, nil
+		})
+		assert.NoError(nt, resultErr)
+		assert.Equal(nt, result, []int{})
+	})
+	t.Run("should return error when iterator returns error", func(nt *testing.T) {
+		result, resultErr := async.FilterSlice([]int{2, 7, 8}, func(val int, idx int) (bool, error) {
+			return true, errors.New("an error")
+		})
+		assert.Error(nt, resultErr)
+		assert.Nil(nt, result)
+	})
+	t.Run("should return error when iterator panics", func(nt *testing.T) {
+		result, resultErr := async.FilterSlice([]int{2, 7, 8}, func(val int, idx int) (bool, error) {
+			if val == 7 {
+				panic("an error")
+			}
+			return true, nil
+		})
+		assert.EqualError(nt, resultErr, "panic in function: an error")
+		assert.Nil(nt, result)
+	})
+}
+
+func TestFilterSliceLimit(t *testing.T) {
+	t.Run("should return values passing test in original order within limit", func(nt *testing.T) {
+		collection := []int{2, 7, 8, 9, 1, 3, 4, 6}
+		maxLimit := 3
+		var running, limitExceeded int32
+		result, resultErr := async.FilterSliceLimit(collection, func(val int, idx int) (bool, error) {
+			if atomic.AddInt32(&running, 1) > int32(maxLimit) {
+				atomic.StoreInt32(&limitExceeded, 1)
+			}
+			defer atomic.AddInt32(&running, -1)
+			time.Sleep(time.Duration(rand.Intn(100)) * time.Millisecond)
+			return val%2 == 0, nil
+		}, maxLimit)
+		assert.NoError(nt, resultErr)
+		assert.Equal(nt, result, []int{2, 8, 4, 6})
+		assert.Equal(nt, atomic.LoadInt32(&limitExceeded), int32(0))
+	})
+	t.Run("should return error when iterator returns error", func(nt *testing.T) {
+		result, resultErr := async.FilterSliceLimit([]int{2, 7, 8, 9}, func(val int, idx int) (bool, error) {
+			if idx == 2 {
+				return false, errors.New("an error")
+			}
+			return true, nil
+		}, 2)
+		assert.Error(nt, resultErr)
+		assert.Nil(nt, result)
+	})
+}
+
+func TestRejectSlice(t *testing.T) {
+	t.Run("should return values failing test in original order", func(nt *testing.T) {
+		collection := []int{2, 7, 8, 9, 1, 3, 4, 6}
+		result, resultErr := async.RejectSlice(collection, func(val int, idx int) (bool, error) {
+			time.Sleep(time.Duration(rand.Intn(100)) * time.Millisecond)
+			return val%2 == 0, nil
+		})
+		assert.NoError(nt, resultErr)
+		assert.Equal(nt, result, []int{7, 9, 1, 3})
+	})
+	t.Run("should return error when iterator returns error", func(nt *testing.T) {
+		result, resultErr := async.RejectSlice([]int{2, 7, 8}, func(val int, idx int) (bool, error) {
+			return true, errors.New("an error")
+		})
+		assert.Error(nt, resultErr)
+		assert.Nil(nt, result)
+	})
+	t.Run("should return error when iterator panics", func(nt *testing.T) {
+		result, resultErr := async.RejectSlice([]int{2, 7, 8}, func(val int, idx int) (bool, error) {
+			panic("an error")
+		})
+		assert.EqualError(nt, resultErr, "panic in function: an error")
+		assert.Nil(nt, result)
+	})
+}
+
+func TestRejectSliceLimit(t *testing.T) {
+	t.Run("should return values failing test in original order within limit", func(nt *testing.T) {
+		collection := []int{2, 7, 8, 9, 1, 3, 4, 6}
+		maxLimit := 3
+		var running, limitExceeded int32
+		result, resultErr := async.RejectSliceLimit(collection, func(val int, idx int) (bool, error) {
+			if atomic.AddInt32(&running, 1) > int32(maxLimit) {
+				atomic.StoreInt32(&limitExceeded, 1)
+			}
+			defer atomic.AddInt32(&running, -1)
+			time.Sleep(time.Duration(rand.Intn(100)) * time.Millisecond)
+			return val%2 == 0, nil
+		}, maxLimit)
+		assert.NoError(nt, resultErr)
+		assert.Equal(nt, result, []int{7, 9, 1, 3})
+		assert.Equal(nt, atomic.LoadInt32(&limitExceeded), int32(0))
+	})
+	t.Run("should return error when iterator returns error", func(nt *testing.T) {
+		result, resultErr := async.RejectSliceLimit([]int{2, 7, 8, 9}, func(val int, idx int) (bool, error) {
+			if idx == 1 {
+				return false, errors.New("an error")
+			}
+			return true, nil
+		}, 2)
+		assert.Error(nt, resultErr)
+		assert.Nil(nt, result)
+	})
+}
+
+func TestEverySlice(t *testing.T) {
+	t.Run("should return true when every value passes test", func(nt *testing.T) {
+		result, resultErr := async.EverySlice([]int{2, 4, 6, 8}, func(val int, idx int) (bool, error) {
+			time.Sleep(time.Duration(rand.Intn(100)) * time.Millisecond)
+			return val%2 == 0, nil
+		})
+		assert.NoError(nt, resultErr)
+		assert.True(nt, result)
+	})
+	t.Run("should return true for empty collection", func(nt *testing.T) {
+		result, resultErr := async.EverySlice([]int{}, func(val int, idx int) (bool, error) {
+			return false, nil
+		})
+		assert.NoError(nt, resultErr)
+		assert.True(nt, result)
+	})
+	t.Run("should return false without waiting for slow values", func(nt *testing.T) {
+		start := time.Now()
+		result, resultErr := async.EverySlice([]int{2, 3, 6, 8}, func(val int, idx int) (bool, error) {
+			if val != 3 {
+				time.Sleep(500 * time.Millisecond)
+			}
+			return val%2 == 0, nil
+		})
+		assert.NoError(nt, resultErr)
+		assert.False(nt, result)
+		assert.Less(nt, time.Since(start), 400*time.Millisecond)
+	})
+	t.Run("should return error when iterator returns error", func(nt *testing.T) {
+		result, resultErr := async.EverySlice([]int{2, 4, 6}, func(val int, idx int) (bool, error) {
+			return true, errors.New("an error")
+		})
+		assert.Error(nt, resultErr)
+		assert.False(nt, result)
+	})
+	t.Run("should return error when iterator panics", func(nt *testing.T) {
+		result, resultErr := async.EverySlice([]int{2, 4, 6}, func(val int, idx int) (bool, error) {
+			panic("an error")
+		})
+		assert.EqualError(nt, resultErr, "panic in function: an error")
+		assert.False(nt, result)
+	})
+}
+
+func TestEverySliceLimit(t *testing.T) {
+	t.Run("should return true when every value passes test within limit", func(nt *testing.T) {
+		maxLimit := 2
+		var running, limitExceeded int32
+		result, resultErr := async.EverySliceLimit([]int{2, 4, 6, 8, 10, 12}, func(val int, idx int) (bool, error) {
+			if atomic.AddInt32(&running, 1) > int32(maxLimit) {
+				atomic.StoreInt32(&limitExceeded, 1)
+			}
+			defer atomic.AddInt32(&running, -1)
+			time.Sleep(time.Duration(rand.Intn(20)) * time.Millisecond)
+			return val%2 == 0, nil
+		}, maxLimit)
+		assert.NoError(nt, resultErr)
+		assert.True(nt, result)
+		assert.Equal(nt, atomic.LoadInt32(&limitExceeded), int32(0))
+	})
+	t.Run("should skip pending values once a test fails", func(nt *testing.T) {
+		var calls int32
+		result, resultErr := async.EverySliceLimit([]int{3, 2, 4, 6, 8, 10}, func(val int, idx int) (bool, error) {
+			atomic.AddInt32(&calls, 1)
+			time.Sleep(10 * time.Millisecond)
+			return val%2 == 0, nil
+		}, 1)
+		assert.NoError(nt, resultErr)
+		assert.False(nt, result)
+		time.Sleep(50 * time.Millisecond)
+		assert.Less(nt, atomic.LoadInt32(&calls), int32(6))
+	})
+}
+
+func TestSomeSlice(t *testing.T) {
+	t.Run("should return true without waiting for slow values", func(nt *testing.T) {
+		start := time.Now()
+		result, resultErr := async.SomeSlice([]int{1, 3, 6, 7}, func(val int, idx int) (bool, error) {
+			if val != 6 {
+				time.Sleep(500 * time.Millisecond)
+			}
+			return val%2 == 0, nil
+		})
+		assert.NoError(nt, resultErr)
+		assert.True(nt, result)
+		assert.Less(nt, time.Since(start), 400*time.Millisecond)
+	})
+	t.Run("should return false when no value passes test", func(nt *testing.T) {
+		result, resultErr := async.SomeSlice([]int{1, 3, 5}, func(val int, idx int) (bool, error) {
+			time.Sleep(time.Duration(rand.Intn(100)) * time.Millisecond)
+			return val%2 == 0, nil
+		})
+		assert.NoError(nt, resultErr)
+		assert.False(nt, result)
+	})
+	t.Run("should return false for empty collection", func(nt *testing.T) {
+		result, resultErr := async.SomeSlice([]int{}, func(val int, idx int) (bool, error) {
+			return true, nil
+		})
+		assert.NoError(nt, resultErr)
+		assert.False(nt, result)
+	})
+	t.Run("should return error when iterator panics", func(nt *testing.T) {
+		result, resultErr := async.SomeSlice([]int{1, 3}, func(val int, idx int) (bool, error) {
+			panic("an error")
+		})
+		assert.EqualError(nt, resultErr, "panic in function: an error")
+		assert.False(nt, result)
+	})
+}
+
+func TestSomeSliceLimit(t *testing.T) {
+	t.Run("should return false for empty collection", func(nt *testing.T) {
+		result, resultErr := async.SomeSliceLimit([]int{}, func(val int, idx int) (bool, error) {
+			return true, nil
+		}, 2)
+		assert.NoError(nt, resultErr)
+		assert.False(nt, result)
+	})
+	t.Run("should return true when a value passes test within limit", func(nt *testing.T) {
+		maxLimit := 2
+		var running, limitExceeded int32
+		result, resultErr := async.SomeSliceLimit([]int{1, 3, 5, 7, 8, 9}, func(val int, idx int) (bool, error) {
+			if atomic.AddInt32(&running, 1) > int32(maxLimit) {
+				atomic.StoreInt32(&limitExceeded, 1)
+			}
+			defer atomic.AddInt32(&running, -1)
+			time.Sleep(time.Duration(rand.Intn(20)) * time.Millisecond)
+			return val%2 == 0, nil
+		}, maxLimit)
+		assert.NoError(nt, resultErr)
+		assert.True(nt, result)
+		assert.Equal(nt, atomic.LoadInt32(&limitExceeded), int32(0))
+	})
+	t.Run("should return error when iterator returns error", func(nt *testing.T) {
+		result, resultErr := async.SomeSliceLimit([]int{1, 3, 5}, func(val int, idx int) (bool, error) {
+			return false, errors.New("an error")
+		}, 2)
+		assert.Error(nt, resultErr)
+		assert.False(nt, result)
+	})
+}
+
+func TestDetectSlice(t *testing.T) {
+	t.Run("should return lowest index match even if it finishes last", func(nt *testing.T) {
+		collection := []int{1, 4, 5, 6, 8}
+		value, idx, detected, err := async.DetectSlice(collection, func(val int, idx int) (bool, error) {
+			if val == 4 {
+				time.Sleep(100 * time.Millisecond)
+			}
+			return val%2 == 0, nil
+		})
+		assert.NoError(nt, err)
+		assert.True(nt, detected)
+		assert.Equal(nt, value, 4)
+		assert.Equal(nt, idx, 1)
+	})
+	t.Run("should return zero value and -1 when no value matches", func(nt *testing.T) {
+		value, idx, detected, err := async.DetectSlice([]int{1, 3, 5}, func(val int, idx int) (bool, error) {
+			time.Sleep(time.Duration(rand.Intn(100)) * time.Millisecond)
+			return val%2 == 0, nil
+		})
+		assert.NoError(nt, err)
+		assert.False(nt, detected)
+		assert.Equal(nt, value, 0)
+		assert.Equal(nt, idx, -1)
+	})
+	t.Run("should return error when iterator returns error", func(nt *testing.T) {
+		value, idx, detected, err := async.DetectSlice([]int{1, 4, 5}, func(val int, idx int) (bool, error) {
+			return false, errors.New("an error")
+		})
+		assert.Error(nt, err)
+		assert.False(nt, detected)
+		assert.Equal(nt, value, 0)
+		assert.Equal(nt, idx, -1)
+	})
+	t.Run("should return error when iterator panics", func(nt *testing.T) {
+		_, _, detected, err := async.DetectSlice([]int{1, 4, 5}, func(val int, idx int) (bool, error) {
+			panic("an error")
+		})
+		assert.EqualError(nt, err, "panic in function: an error")
+		assert.False(nt, detected)
+	})
+}
+
+func TestReduceSlice(t *testing.T) {
+	t.Run("should return values folded in order", func(nt *testing.T) {
+		collection := []string{"the brown", "fox", "jumps over the", "brown fence"}
+		result, resultErr := async.ReduceSlice(collection, func(acc string, val string, idx int) (string, error) {
+			return strings.TrimLeft(acc+" "+val, " "), nil
+		}, "")
+		assert.NoError(nt, resultErr)
+		assert.Equal(nt, result, "the brown fox jumps over the brown fence")
+	})
+	t.Run("should return error when reducer returns error", func(nt *testing.T) {
+		result, resultErr := async.ReduceSlice([]int{2, 7, 8}, func(acc int, val int, idx int) (int, error) {
+			if idx == 2 {
+				return 0, errors.New("an error")
+			}
+			return acc + val, nil
+		}, 0)
+		assert.EqualError(nt, resultErr, "an error")
+		assert.Equal(nt, result, 9)
+	})
+	t.Run("should return error when reducer panics", func(nt *testing.T) {
+		result, resultErr := async.ReduceSlice([]int{2, 7, 8}, func(acc int, val int, idx int) (int, error) {
+			if idx == 1 {
+				panic("an error")
+			}
+			return acc + val, nil
+		}, 0)
+		assert.EqualError(nt, resultErr, "panic in function: an error")
+		assert.Equal(nt, result, 2)
+	})
+}
+
+func TestGroupBySlice(t *testing.T) {
+	t.Run("should return grouped values", func(nt *testing.T) {
+		collection := []string{"the brown", "fox", "jumps over the", "brown fence", "fly"}
+		collectionResult := map[int][]string{9: {"the brown"}, 3: {"fox", "fly"}, 14: {"jumps over the"}, 11: {"brown fence"}}
+		grouped, groupedErr := async.GroupBySlice(collection, func(val string, idx int) (int, string, error) {
+			time.Sleep(time.Duration(rand.Intn(100)) * time.Millisecond)
+			return len(val), val, nil
+		})
+		assert.NoError(nt, groupedErr)
+		assert.Equal(nt, grouped, collectionResult)
+	})
+	t.Run("should return error when iterator returns error", func(nt *testing.T) {
+		grouped, groupedErr := async.GroupBySlice([]string{"the brown", "fox"}, func(val string, idx int) (int, string, error) {
+			return len(val), val, errors.New("an error")
+		})
+		assert.Error(nt, groupedErr)
+		assert.Nil(nt, grouped)
+	})
+	t.Run("should return error when iterator panics", func(nt *testing.T) {
+		grouped, groupedErr := async.GroupBySlice([]string{"the brown", "fox"}, func(val string, idx int) (int, string, error) {
+			panic("an error")
+		})
+		assert.EqualError(nt, groupedErr, "panic in function: an error")
+		assert.Nil(nt, grouped)
+	})
+}
+
+func TestGroupBySliceLimit(t *testing.T) {
+	t.Run("should return grouped values within limit", func(nt *testing.T) {
+		collection := []string{"the brown", "fox", "jumps over the", "brown fence", "fly"}
+		collectionResult := map[int][]string{9: {"the brown"}, 3: {"fox", "fly"}, 14: {"jumps over the"}, 11: {"brown fence"}}
+		maxLimit := 2
+		var running, limitExceeded int32
+		grouped, groupedErr := async.GroupBySliceLimit(collection, func(val string, idx int) (int, string, error) {
+			if atomic.AddInt32(&running, 1) > int32(maxLimit) {
+				atomic.StoreInt32(&limitExceeded, 1)
+			}
+			defer atomic.AddInt32(&running, -1)
+			time.Sleep(time.Duration(rand.Intn(100)) * time.Millisecond)
+			return len(val), val, nil
+		}, maxLimit)
+		assert.NoError(nt, groupedErr)
+		assert.Equal(nt, grouped, collectionResult)
+		assert.Equal(nt, atomic.LoadInt32(&limitExceeded), int32(0))
+	})
+}
+
+func TestConcatSlice(t *testing.T) {
+	t.Run("should return concatenated values in original order", func(nt *testing.T) {
+		collection := []string{"the brown", "fox", "jumps over the", "brown fence"}
+		collectionResult := []string{"the", "brown", "fox", "jumps", "over", "the", "brown", "fence"}
+		result, resultErr := async.ConcatSlice(collection, func(val string, idx int) ([]string, error) {
+			time.Sleep(time.Duration(rand.Intn(100)) * time.Millisecond)
+			return strings.Split(val, " "), nil
+		})
+		assert.NoError(nt, resultErr)
+		assert.Equal(nt, result, collectionResult)
+	})
+	t.Run("should return error when iterator returns error", func(nt *testing.T) {
+		result, resultErr := async.ConcatSlice([]string{"the brown", "fox"}, func(val string, idx int) ([]string, error) {
+			return strings.Split(val, " "), errors.New("an error")
+		})
+		assert.Error(nt, resultErr)
+		assert.Nil(nt, result)
+	})
+	t.Run("should return error when iterator panics", func(nt *testing.T) {
+		result, resultErr := async.ConcatSlice([]string{"the brown", "fox"}, func(val string, idx int) ([]string, error) {
+			panic("an error")
+		})
+		assert.EqualError(nt, resultErr, "panic in function: an error")
+		assert.Nil(nt, result)
+	})
+}
+
+func TestConcatSliceLimit(t *testing.T) {
+	t.Run("should return concatenated values in original order within limit", func(nt *testing.T) {
+		collection := []string{"the brown", "fox", "jumps over the", "brown fence"}
+		collectionResult := []string{"the", "brown", "fox", "jumps", "over", "the", "brown", "fence"}
+		maxLimit := 2
+		var running, limitExceeded int32
+		result, resultErr := async.ConcatSliceLimit(collection, func(val string, idx int) ([]string, error) {
+			if atomic.AddInt32(&running, 1) > int32(maxLimit) {
+				atomic.StoreInt32(&limitExceeded, 1)
+			}
+			defer atomic.AddInt32(&running, -1)
+			time.Sleep(time.Duration(rand.Intn(100)) * time.Millisecond)
+			return strings.Split(val, " "), nil
+		}, maxLimit)
+		assert.NoError(nt, resultErr)
+		assert.Equal(nt, result, collectionResult)
+		assert.Equal(nt, atomic.LoadInt32(&limitExceeded), int32(0))
+	})
+}
+
+func TestSliceRetry(t *testing.T) {
+	t.Run("should retry failing elements", func(nt *testing.T) {
+		collection := []int{1, 2, 3, 4, 5, 6}
+		calls := make([]int32, len(collection))
+		result, resultErr := async.SliceRetry(collection, func(val int) (int, error) {
+			time.Sleep(time.Duration(rand.Intn(100)) * time.Millisecond)
+			if atomic.AddInt32(&calls[val-1], 1) == 1 && val%2 == 0 {
+				return 0, errors.New("flaky")
+			}
+			return val * 2, nil
+		}, 2, 3)
+		assert.NoError(nt, resultErr)
+		assert.Equal(nt, result, []int{2, 4, 6, 8, 10, 12})
+		assert.Equal(nt, calls, []int32{1, 2, 1, 2, 1, 2})
+	})
+	t.Run("should return error after exhausting attempts", func(nt *testing.T) {
+		collection := []int{1, 2, 3}
+		var calls int32
+		result, resultErr := async.SliceRetry(collection, func(val int) (int, error) {
+			if val == 2 {
+				atomic.AddInt32(&calls, 1)
+				return 0, errors.New("always fails")
+			}
+			return val, nil
+		}, 3, 1)
+		assert.EqualError(nt, resultErr, "always fails")
+		assert.Nil(nt, result)
+		assert.Equal(nt, atomic.LoadInt32(&calls), int32(3))
+	})
+	t.Run("should respect limit", func(nt *testing.T) {
+		collection := []int{1, 2, 3, 4, 5, 6, 7, 8}
+		var running, limitExceeded int32
+		_, resultErr := async.SliceRetry(collection, func(val int) (int, error) {
+			if atomic.AddInt32(&running, 1) > 2 {
+				atomic.StoreInt32(&limitExceeded, 1)
+			}
+			time.Sleep(time.Duration(rand.Intn(20)) * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			return val, nil
+		}, 1, 2)
+		assert.NoError(nt, resultErr)
+		assert.Equal(nt, atomic.LoadInt32(&limitExceeded), int32(0))
+	})
+}
+
+func TestEachBatchSliceLimit(t *testing.T) {
+	t.Run("should call fn with correct batches", func(nt *testing.T) {
+		collection := []int{1, 2, 3, 4, 5, 6, 7}
+		mu := sync.Mutex{}
+		batches := make([][]int, 0)
+		err := async.EachBatchSliceLimit(collection, 3, func(batch []int) error {
+			time.Sleep(time.Duration(rand.Intn(100)) * time.Millisecond)
+			mu.Lock()
+			batches = append(batches, batch)
+			mu.Unlock()
+			return nil
+		}, 2)
+		assert.NoError(nt, err)
+		assert.ElementsMatch(nt, batches, [][]int{{1, 2, 3}, {4, 5, 6}, {7}})
+	})
+	t.Run("should respect limit", func(nt *testing.T) {
+		collection := make([]int, 40)
+		var running, limitExceeded int32
+		err := async.EachBatchSliceLimit(collection, 4, func(batch []int) error {
+			if atomic.AddInt32(&running, 1) > 3 {
+				atomic.StoreInt32(&limitExceeded, 1)
+			}
+			time.Sleep(time.Duration(rand.Intn(20)) * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			return nil
+		}, 3)
+		assert.NoError(nt, err)
+		assert.Equal(nt, atomic.LoadInt32(&limitExceeded), int32(0))
+	})
+	t.Run("should return first error and skip pending batches", func(nt *testing.T) {
+		collection := make([]int, 20)
+		var calls int32
+		err := async.EachBatchSliceLimit(collection, 2, func(batch []int) error {
+			atomic.AddInt32(&calls, 1)
+			return errors.New("some error")
+		}, 1)
+		assert.EqualError(nt, err, "some error")
+		assert.Less(nt, atomic.LoadInt32(&calls), int32(10))
+	})
+	t.Run("should recover panics as errors", func(nt *testing.T) {
+		err := async.EachBatchSliceLimit([]int{1, 2, 3}, 2, func(batch []int) error {
+			if batch[0] == 3 {
+				panic("boom")
+			}
+			return nil
+		}, 2)
+		assert.EqualError(nt, err, "panic in function: boom")
+	})
+	t.Run("should return error for invalid size", func(nt *testing.T) {
+		err := async.EachBatchSlice([]int{1, 2}, 0, func(batch []int) error {
+			return nil
+		})
+		assert.ErrorIs(nt, err, goutils.ErrInvalidBatchSize)
+		err = async.EachBatchSliceLimit([]int{1, 2}, -1, func(batch []int) error {
+			return nil
+		}, 1)
+		assert.ErrorIs(nt, err, goutils.ErrInvalidBatchSize)
+	})
+}
@@ -0,0 +1,20 @@
+package goutils
+
+// Coalesce returns the first value which is not the zero value of its type,
+// or the zero value if all values are zero.
+func Coalesce[A comparable](values ...A) A {
+	var zero A
+	return CoalesceFunc(func(value A) bool { return value == zero }, values...)
+}
+
+// CoalesceFunc returns the first value for which isEmpty returns false,
+// or the zero value if all values are empty.
+func CoalesceFunc[A any](isEmpty func(value A) bool, values ...A) A {
+	for _, value := range values {
+		if !isEmpty(value) {
+			return value
+		}
+	}
+	var zero A
+	return zero
+}
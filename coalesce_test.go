@@ -0,0 +1,34 @@
+package goutils_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/skatiyar/goutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoalesce(t *testing.T) {
+	t.Run("should skip leading zero values", func(nt *testing.T) {
+		assert.Equal(nt, goutils.Coalesce(0, 0, 3, 4), 3)
+		assert.Equal(nt, goutils.Coalesce("", "default", "other"), "default")
+	})
+	t.Run("should return zero value if all values are zero", func(nt *testing.T) {
+		assert.Equal(nt, goutils.Coalesce(0, 0), 0)
+		assert.Equal(nt, goutils.Coalesce[string](), "")
+	})
+}
+
+func TestCoalesceFunc(t *testing.T) {
+	isBlank := func(value string) bool { return strings.TrimSpace(value) == "" }
+	t.Run("should use custom emptiness test", func(nt *testing.T) {
+		assert.Equal(nt, goutils.CoalesceFunc(isBlank, "", "  ", "value"), "value")
+	})
+	t.Run("should return zero value if all values are empty", func(nt *testing.T) {
+		assert.Equal(nt, goutils.CoalesceFunc(isBlank, " ", "\t"), "")
+	})
+	t.Run("should work with non comparable values", func(nt *testing.T) {
+		result := goutils.CoalesceFunc(func(value []int) bool { return len(value) == 0 }, nil, []int{}, []int{1, 2})
+		assert.Equal(nt, result, []int{1, 2})
+	})
+}
@@ -0,0 +1,121 @@
+package control
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var (
+	ErrDependencyCycle   = errors.New("tasks have a dependency cycle")
+	ErrUnknownDependency = errors.New("task depends on unknown task")
+)
+
+// Task is a node of the dependency graph run by Auto. Dependencies names the tasks whose results
+// Fn needs, and Fn receives those results keyed by task name.
+type Task struct {
+	Dependencies []string
+	Fn           func(ctx context.Context, deps map[string]any) (any, error)
+}
+
+// Auto runs the tasks in dependency order, starting each task as soon as all of its dependencies complete,
+// so independent tasks run concurrently. It returns the results of all tasks keyed by task name.
+// The graph is checked before any task runs, returning ErrUnknownDependency or ErrDependencyCycle if it is invalid.
+// If any task returns an error or panics, the context passed to running tasks is cancelled, no further tasks are started,
+// and once running tasks return, the function returns results of the completed tasks with the first error.
+func Auto(tasks map[string]Task) (map[string]any, error) {
+	pending := make(map[string]int, len(tasks))
+	dependents := make(map[string][]string, len(tasks))
+	for name, task := range tasks {
+		pending[name] = len(task.Dependencies)
+		for _, dep := range task.Dependencies {
+			if _, ok := tasks[dep]; !ok {
+				return nil, fmt.Errorf("%w: %s depends on %s", ErrUnknownDependency, name, dep)
+			}
+			dependents[dep] = append(dependents[dep], name)
+		}
+	}
+	ready := make([]string, 0)
+	for name, count := range pending {
+		if count == 0 {
+			ready = append(ready, name)
+		}
+	}
+	if !isAcyclic(ready, pending, dependents) {
+		return nil, ErrDependencyCycle
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	results := make(map[string]any, len(tasks))
+	var firstErr error
+	mu := sync.Mutex{}
+	wg := sync.WaitGroup{}
+	var start func(name string)
+	start = func(name string) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			task := tasks[name]
+			mu.Lock()
+			deps := make(map[string]any, len(task.Dependencies))
+			for _, dep := range task.Dependencies {
+				deps[dep] = results[dep]
+			}
+			mu.Unlock()
+			var result any
+			err := callSafe(func() (ferr error) {
+				result, ferr = task.Fn(ctx, deps)
+				return
+			})
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+					cancel()
+				}
+				return
+			}
+			results[name] = result
+			if firstErr != nil {
+				return
+			}
+			for _, dependent := range dependents[name] {
+				pending[dependent] -= 1
+				if pending[dependent] == 0 {
+					start(dependent)
+				}
+			}
+		}()
+	}
+	for _, name := range ready {
+		start(name)
+	}
+	wg.Wait()
+	return results, firstErr
+}
+
+// isAcyclic reports whether all tasks can be ordered topologically, starting from the ready tasks.
+// pending is not modified.
+func isAcyclic(ready []string, pending map[string]int, dependents map[string][]string) bool {
+	remaining := make(map[string]int, len(pending))
+	for name, count := range pending {
+		remaining[name] = count
+	}
+	queue := append([]string{}, ready...)
+	visited := 0
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		visited += 1
+		for _, dependent := range dependents[name] {
+			remaining[dependent] -= 1
+			if remaining[dependent] == 0 {
+				queue = append(queue, dependent)
+			}
+		}
+	}
+	return visited == len(pending)
+}
@@ -0,0 +1,128 @@
+package control_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/skatiyar/goutils/control"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuto(t *testing.T) {
+	t.Run("should pass dependency results and run independent tasks concurrently", func(nt *testing.T) {
+		start := time.Now()
+		results, resultsErr := control.Auto(map[string]control.Task{
+			"left": {
+				Fn: func(ctx context.Context, deps map[string]any) (any, error) {
+					time.Sleep(100 * time.Millisecond)
+					return 2, nil
+				},
+			},
+			"right": {
+				Fn: func(ctx context.Context, deps map[string]any) (any, error) {
+					time.Sleep(100 * time.Millisecond)
+					return 3, nil
+				},
+			},
+			"product": {
+				Dependencies: []string{"left", "right"},
+				Fn: func(ctx context.Context, deps map[string]any) (any, error) {
+					return deps["left"].(int) * deps["right"].(int), nil
+				},
+			},
+			"double": {
+				Dependencies: []string{"product"},
+				Fn: func(ctx context.Context, deps map[string]any) (any, error) {
+					return deps["product"].(int) * 2, nil
+				},
+			},
+		})
+		assert.NoError(nt, resultsErr)
+		assert.Less(nt, time.Since(start), 180*time.Millisecond)
+		assert.Equal(nt, results, map[string]any{"left": 2, "right": 3, "product": 6, "double": 12})
+	})
+	t.Run("should detect cycles without running tasks", func(nt *testing.T) {
+		var calls int32
+		fn := func(ctx context.Context, deps map[string]any) (any, error) {
+			atomic.AddInt32(&calls, 1)
+			return nil, nil
+		}
+		results, resultsErr := control.Auto(map[string]control.Task{
+			"root": {Fn: fn},
+			"a":    {Dependencies: []string{"root", "c"}, Fn: fn},
+			"b":    {Dependencies: []string{"a"}, Fn: fn},
+			"c":    {Dependencies: []string{"b"}, Fn: fn},
+		})
+		assert.ErrorIs(nt, resultsErr, control.ErrDependencyCycle)
+		assert.Nil(nt, results)
+		assert.Equal(nt, atomic.LoadInt32(&calls), int32(0))
+	})
+	t.Run("should return error for unknown dependency", func(nt *testing.T) {
+		_, resultsErr := control.Auto(map[string]control.Task{
+			"a": {
+				Dependencies: []string{"missing"},
+				Fn: func(ctx context.Context, deps map[string]any) (any, error) {
+					return nil, nil
+				},
+			},
+		})
+		assert.ErrorIs(nt, resultsErr, control.ErrUnknownDependency)
+	})
+	t.Run("should stop on error and cancel running tasks", func(nt *testing.T) {
+		dependentCalled := false
+		results, resultsErr := control.Auto(map[string]control.Task{
+			"failing": {
+				Fn: func(ctx context.Context, deps map[string]any) (any, error) {
+					return nil, errors.New("some error")
+				},
+			},
+			"slow": {
+				Fn: func(ctx context.Context, deps map[string]any) (any, error) {
+					select {
+					case <-ctx.Done():
+						return nil, ctx.Err()
+					case <-time.After(time.Second):
+						return "slow", nil
+					}
+				},
+			},
+			"dependent": {
+				Dependencies: []string{"failing"},
+				Fn: func(ctx context.Context, deps map[string]any) (any, error) {
+					dependentCalled = true
+					return nil, nil
+				},
+			},
+		})
+		assert.EqualError(nt, resultsErr, "some error")
+		assert.False(nt, dependentCalled)
+		assert.Empty(nt, results)
+	})
+	t.Run("should return panic as error", func(nt *testing.T) {
+		dependentCalled := false
+		_, resultsErr := control.Auto(map[string]control.Task{
+			"panicking": {
+				Fn: func(ctx context.Context, deps map[string]any) (any, error) {
+					panic("boom")
+				},
+			},
+			"dependent": {
+				Dependencies: []string{"panicking"},
+				Fn: func(ctx context.Context, deps map[string]any) (any, error) {
+					dependentCalled = true
+					return nil, nil
+				},
+			},
+		})
+		assert.EqualError(nt, resultsErr, "panic in function: boom")
+		assert.False(nt, dependentCalled)
+	})
+	t.Run("should return empty results without tasks", func(nt *testing.T) {
+		results, resultsErr := control.Auto(map[string]control.Task{})
+		assert.NoError(nt, resultsErr)
+		assert.Empty(nt, results)
+	})
+}
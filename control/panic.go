@@ -0,0 +1,21 @@
+package control
+
+import (
+	"fmt"
+)
+
+// callSafe calls fn, converting a panic into an error, so a panicking task started on its own goroutine
+// fails like any other task instead of crashing the process.
+// Non-error panic values are wrapped as "panic in function: <value>".
+func callSafe(fn func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if rerr, ok := r.(error); ok {
+				err = rerr
+			} else {
+				err = fmt.Errorf("panic in function: %v", r)
+			}
+		}
+	}()
+	return fn()
+}
@@ -0,0 +1,64 @@
+package control
+
+import (
+	"context"
+	"sync"
+)
+
+// mergedContext resolves values from a list of contexts, preferring later contexts.
+// Cancellation and deadline come from the embedded context.
+type mergedContext struct {
+	context.Context
+	contexts []context.Context
+}
+
+func (mc *mergedContext) Value(key any) any {
+	for idx := len(mc.contexts) - 1; idx >= 0; idx -= 1 {
+		if value := mc.contexts[idx].Value(key); value != nil {
+			return value
+		}
+	}
+	return mc.Context.Value(key)
+}
+
+// Parallel runs the executors concurrently, each receiving the same base context,
+// and returns a context holding the values of all contexts returned by the executors.
+// When executors set the same key, the value from the executor later in the argument list wins.
+// If any executor returns an error or panics, the base context is cancelled to signal the rest,
+// and once all executors return, the function returns the error which occurred first
+// along with the values of the executors which succeeded.
+func Parallel(executors ...func(context.Context) (context.Context, error)) (context.Context, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	results := make([]context.Context, len(executors))
+	var firstErr error
+	errOnce := sync.Once{}
+	wg := sync.WaitGroup{}
+	for idx := range executors {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			var execCtx context.Context
+			execErr := callSafe(func() (err error) {
+				execCtx, err = executors[i](ctx)
+				return
+			})
+			if execErr != nil {
+				errOnce.Do(func() {
+					firstErr = execErr
+					cancel()
+				})
+			} else {
+				results[i] = execCtx
+			}
+		}(idx)
+	}
+	wg.Wait()
+	merged := &mergedContext{Context: context.Background(), contexts: make([]context.Context, 0, len(results))}
+	for _, result := range results {
+		if result != nil {
+			merged.contexts = append(merged.contexts, result)
+		}
+	}
+	return merged, firstErr
+}
@@ -0,0 +1,98 @@
+package control_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/skatiyar/goutils/control"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParallel(t *testing.T) {
+	t.Run("should run executors concurrently and merge values", func(nt *testing.T) {
+		start := time.Now()
+		fctx, fctxErr := control.Parallel(
+			func(ctx context.Context) (context.Context, error) {
+				time.Sleep(100 * time.Millisecond)
+				return control.SetControlContextValue(ctx, "First", "Hello"), nil
+			},
+			func(ctx context.Context) (context.Context, error) {
+				time.Sleep(100 * time.Millisecond)
+				return control.SetControlContextValue(ctx, "Second", "World"), nil
+			},
+			func(ctx context.Context) (context.Context, error) {
+				time.Sleep(100 * time.Millisecond)
+				return ctx, nil
+			},
+		)
+		assert.NoError(nt, fctxErr)
+		assert.Less(nt, time.Since(start), 250*time.Millisecond)
+		first, firstErr := control.GetControlContextValue[string, string](fctx, "First")
+		assert.NoError(nt, firstErr)
+		assert.Equal(nt, first, "Hello")
+		second, secondErr := control.GetControlContextValue[string, string](fctx, "Second")
+		assert.NoError(nt, secondErr)
+		assert.Equal(nt, second, "World")
+		assert.NoError(nt, fctx.Err())
+	})
+	t.Run("should resolve key collisions with later executor", func(nt *testing.T) {
+		fctx, fctxErr := control.Parallel(
+			func(ctx context.Context) (context.Context, error) {
+				return control.SetControlContextValue(ctx, "Key", "First"), nil
+			},
+			func(ctx context.Context) (context.Context, error) {
+				time.Sleep(50 * time.Millisecond)
+				return control.SetControlContextValue(ctx, "Key", "Second"), nil
+			},
+			func(ctx context.Context) (context.Context, error) {
+				return control.SetControlContextValue(ctx, "Key", "Third"), nil
+			},
+		)
+		assert.NoError(nt, fctxErr)
+		value, valueErr := control.GetControlContextValue[string, string](fctx, "Key")
+		assert.NoError(nt, valueErr)
+		assert.Equal(nt, value, "Third")
+	})
+	t.Run("should cancel other executors and return first error", func(nt *testing.T) {
+		start := time.Now()
+		fctx, fctxErr := control.Parallel(
+			func(ctx context.Context) (context.Context, error) {
+				return ctx, errors.New("some error")
+			},
+			func(ctx context.Context) (context.Context, error) {
+				select {
+				case <-ctx.Done():
+					return ctx, ctx.Err()
+				case <-time.After(time.Second):
+					return control.SetControlContextValue(ctx, "Slow", "Done"), nil
+				}
+			},
+			func(ctx context.Context) (context.Context, error) {
+				return control.SetControlContextValue(ctx, "Fast", "Done"), nil
+			},
+		)
+		assert.EqualError(nt, fctxErr, "some error")
+		assert.Less(nt, time.Since(start), 500*time.Millisecond)
+		_, slowErr := control.GetControlContextValue[string, string](fctx, "Slow")
+		assert.Error(nt, slowErr)
+		fast, fastErr := control.GetControlContextValue[string, string](fctx, "Fast")
+		assert.NoError(nt, fastErr)
+		assert.Equal(nt, fast, "Done")
+	})
+	t.Run("should return panic as error", func(nt *testing.T) {
+		fctx, fctxErr := control.Parallel(
+			func(ctx context.Context) (context.Context, error) {
+				panic("boom")
+			},
+			func(ctx context.Context) (context.Context, error) {
+				return control.SetControlContextValue(ctx, "Fast", "Done"), nil
+			},
+		)
+		assert.EqualError(nt, fctxErr, "panic in function: boom")
+		fast, fastErr := control.GetControlContextValue[string, string](fctx, "Fast")
+		assert.NoError(nt, fastErr)
+		assert.Equal(nt, fast, "Done")
+	})
+}
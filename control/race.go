@@ -0,0 +1,42 @@
+package control
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	ErrNoTasks = errors.New("no tasks provided")
+)
+
+type raceResult[T any] struct {
+	value T
+	err   error
+}
+
+// Race runs the tasks concurrently and returns the value and error of whichever task finishes first.
+// Once a task finishes, the context passed to the tasks is cancelled, so slower tasks should observe it
+// and return early. Their results are discarded without blocking them.
+// A panic in a task is recovered and returned as its error.
+// If no tasks are provided, the function returns the zero value with ErrNoTasks.
+func Race[T any](ctx context.Context, tasks ...func(context.Context) (T, error)) (T, error) {
+	if len(tasks) == 0 {
+		var zero T
+		return zero, ErrNoTasks
+	}
+	raceCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	resultChan := make(chan raceResult[T], len(tasks))
+	for idx := range tasks {
+		go func(i int) {
+			result := raceResult[T]{}
+			result.err = callSafe(func() (err error) {
+				result.value, err = tasks[i](raceCtx)
+				return
+			})
+			resultChan <- result
+		}(idx)
+	}
+	result := <-resultChan
+	return result.value, result.err
+}
@@ -0,0 +1,95 @@
+package control_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/skatiyar/goutils/control"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRace(t *testing.T) {
+	t.Run("should return result of fastest task", func(nt *testing.T) {
+		value, valueErr := control.Race(context.Background(),
+			func(ctx context.Context) (string, error) {
+				select {
+				case <-ctx.Done():
+					return "", ctx.Err()
+				case <-time.After(200 * time.Millisecond):
+					return "slow", nil
+				}
+			},
+			func(ctx context.Context) (string, error) {
+				time.Sleep(10 * time.Millisecond)
+				return "fast", nil
+			},
+		)
+		assert.NoError(nt, valueErr)
+		assert.Equal(nt, value, "fast")
+	})
+	t.Run("should return error of fastest task", func(nt *testing.T) {
+		_, valueErr := control.Race(context.Background(),
+			func(ctx context.Context) (int, error) {
+				return 0, errors.New("some error")
+			},
+			func(ctx context.Context) (int, error) {
+				select {
+				case <-ctx.Done():
+					return 0, ctx.Err()
+				case <-time.After(200 * time.Millisecond):
+					return 1, nil
+				}
+			},
+		)
+		assert.EqualError(nt, valueErr, "some error")
+	})
+	t.Run("should cancel slower tasks", func(nt *testing.T) {
+		wg := sync.WaitGroup{}
+		wg.Add(2)
+		cancelled := make(chan error, 2)
+		slow := func(ctx context.Context) (int, error) {
+			defer wg.Done()
+			select {
+			case <-ctx.Done():
+				cancelled <- ctx.Err()
+				return 0, ctx.Err()
+			case <-time.After(time.Second):
+				return 1, nil
+			}
+		}
+		value, valueErr := control.Race(context.Background(), slow, slow, func(ctx context.Context) (int, error) {
+			return 2, nil
+		})
+		assert.NoError(nt, valueErr)
+		assert.Equal(nt, value, 2)
+		wg.Wait()
+		assert.Len(nt, cancelled, 2)
+		close(cancelled)
+		for err := range cancelled {
+			assert.ErrorIs(nt, err, context.Canceled)
+		}
+	})
+	t.Run("should return panic of fastest task as error", func(nt *testing.T) {
+		_, valueErr := control.Race(context.Background(),
+			func(ctx context.Context) (int, error) {
+				panic("boom")
+			},
+			func(ctx context.Context) (int, error) {
+				select {
+				case <-ctx.Done():
+					return 0, ctx.Err()
+				case <-time.After(200 * time.Millisecond):
+					return 1, nil
+				}
+			},
+		)
+		assert.EqualError(nt, valueErr, "panic in function: boom")
+	})
+	t.Run("should return error without tasks", func(nt *testing.T) {
+		_, valueErr := control.Race[int](context.Background())
+		assert.ErrorIs(nt, valueErr, control.ErrNoTasks)
+	})
+}
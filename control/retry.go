@@ -0,0 +1,72 @@
+package control
+
+import (
+	"context"
+	"time"
+)
+
+// RetryOptions configures RetryWithOptions.
+type RetryOptions struct {
+	// Attempts is the total number of calls of fn, values below one are treated as one.
+	Attempts int
+	// Delay is the wait before the first retry, zero retries immediately.
+	Delay time.Duration
+	// Exponential doubles the delay after every retry.
+	Exponential bool
+	// MaxDelay caps the delay when positive.
+	MaxDelay time.Duration
+	// ShouldRetry reports whether an error is transient, nil retries on every error.
+	ShouldRetry func(error) bool
+}
+
+// Retry calls fn until it succeeds or attempts calls have been made, without waiting between calls.
+// It is same as RetryWithOptions with only Attempts set.
+func Retry[T any](ctx context.Context, attempts int, fn func(context.Context) (T, error)) (T, error) {
+	return RetryWithOptions(ctx, RetryOptions{Attempts: attempts}, fn)
+}
+
+// RetryWithOptions calls fn until it succeeds, attempts are exhausted, or ShouldRetry rejects the error,
+// waiting between calls as configured by options. On failure it returns the last error of fn.
+// If ctx is cancelled before a call or during a delay, the function returns immediately with the context error.
+func RetryWithOptions[T any](ctx context.Context, options RetryOptions, fn func(context.Context) (T, error)) (T, error) {
+	var value T
+	var err error
+	delay := options.Delay
+	for attempt := 0; attempt < options.Attempts || attempt == 0; attempt += 1 {
+		if attempt > 0 {
+			if ctxErr := sleepContext(ctx, delay); ctxErr != nil {
+				return value, ctxErr
+			}
+			if options.Exponential {
+				delay *= 2
+			}
+			if options.MaxDelay > 0 && delay > options.MaxDelay {
+				delay = options.MaxDelay
+			}
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return value, ctxErr
+		}
+		if value, err = fn(ctx); err == nil {
+			return value, nil
+		} else if options.ShouldRetry != nil && !options.ShouldRetry(err) {
+			return value, err
+		}
+	}
+	return value, err
+}
+
+// sleepContext waits for d, returning early with the context error if ctx is cancelled.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,108 @@
+package control_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/skatiyar/goutils/control"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetry(t *testing.T) {
+	t.Run("should return value once fn succeeds", func(nt *testing.T) {
+		calls := 0
+		value, valueErr := control.Retry(context.Background(), 3, func(ctx context.Context) (int, error) {
+			calls += 1
+			if calls < 3 {
+				return 0, errors.New("transient")
+			}
+			return 42, nil
+		})
+		assert.NoError(nt, valueErr)
+		assert.Equal(nt, value, 42)
+		assert.Equal(nt, calls, 3)
+	})
+	t.Run("should return last error on exhaustion", func(nt *testing.T) {
+		calls := 0
+		_, valueErr := control.Retry(context.Background(), 2, func(ctx context.Context) (int, error) {
+			calls += 1
+			return 0, errors.New("attempt failed")
+		})
+		assert.EqualError(nt, valueErr, "attempt failed")
+		assert.Equal(nt, calls, 2)
+	})
+	t.Run("should call fn once with zero attempts", func(nt *testing.T) {
+		calls := 0
+		_, valueErr := control.Retry(context.Background(), 0, func(ctx context.Context) (int, error) {
+			calls += 1
+			return 0, errors.New("some error")
+		})
+		assert.Error(nt, valueErr)
+		assert.Equal(nt, calls, 1)
+	})
+}
+
+func TestRetryWithOptions(t *testing.T) {
+	t.Run("should wait with exponential delay", func(nt *testing.T) {
+		calls := make([]time.Time, 0)
+		_, valueErr := control.RetryWithOptions(context.Background(), control.RetryOptions{
+			Attempts:    3,
+			Delay:       20 * time.Millisecond,
+			Exponential: true,
+		}, func(ctx context.Context) (int, error) {
+			calls = append(calls, time.Now())
+			return 0, errors.New("some error")
+		})
+		assert.Error(nt, valueErr)
+		assert.Len(nt, calls, 3)
+		assert.GreaterOrEqual(nt, calls[1].Sub(calls[0]), 20*time.Millisecond)
+		assert.GreaterOrEqual(nt, calls[2].Sub(calls[1]), 40*time.Millisecond)
+	})
+	t.Run("should cap delay with max delay", func(nt *testing.T) {
+		start := time.Now()
+		_, valueErr := control.RetryWithOptions(context.Background(), control.RetryOptions{
+			Attempts:    5,
+			Delay:       10 * time.Millisecond,
+			Exponential: true,
+			MaxDelay:    10 * time.Millisecond,
+		}, func(ctx context.Context) (int, error) {
+			return 0, errors.New("some error")
+		})
+		assert.Error(nt, valueErr)
+		assert.Less(nt, time.Since(start), 120*time.Millisecond)
+	})
+	t.Run("should stop when error is not retryable", func(nt *testing.T) {
+		permanent := errors.New("permanent")
+		calls := 0
+		_, valueErr := control.RetryWithOptions(context.Background(), control.RetryOptions{
+			Attempts: 5,
+			ShouldRetry: func(err error) bool {
+				return !errors.Is(err, permanent)
+			},
+		}, func(ctx context.Context) (int, error) {
+			calls += 1
+			if calls == 2 {
+				return 0, permanent
+			}
+			return 0, errors.New("transient")
+		})
+		assert.ErrorIs(nt, valueErr, permanent)
+		assert.Equal(nt, calls, 2)
+	})
+	t.Run("should return context error when cancelled during delay", func(nt *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		calls := 0
+		_, valueErr := control.RetryWithOptions(ctx, control.RetryOptions{
+			Attempts: 5,
+			Delay:    time.Second,
+		}, func(ctx context.Context) (int, error) {
+			calls += 1
+			return 0, errors.New("some error")
+		})
+		assert.ErrorIs(nt, valueErr, context.DeadlineExceeded)
+		assert.Equal(nt, calls, 1)
+	})
+}
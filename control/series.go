@@ -0,0 +1,22 @@
+package control
+
+import (
+	"context"
+)
+
+// Series runs the executors one after another, collecting their results positionally.
+// Unlike Waterfall, executors are independent and results are not passed forward.
+// If any executor returns an error, the next executor is not executed,
+// and the function immediately returns the results collected so far with the error.
+func Series[T any](executors ...func(context.Context) (T, error)) ([]T, error) {
+	ctx := context.Background()
+	results := make([]T, 0, len(executors))
+	for idx := range executors {
+		if result, resultErr := executors[idx](ctx); resultErr != nil {
+			return results, resultErr
+		} else {
+			results = append(results, result)
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,56 @@
+package control_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/skatiyar/goutils/control"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeries(t *testing.T) {
+	t.Run("should collect results in order", func(nt *testing.T) {
+		order := make([]int, 0)
+		results, resultsErr := control.Series(
+			func(ctx context.Context) (string, error) {
+				order = append(order, 1)
+				return "a", nil
+			},
+			func(ctx context.Context) (string, error) {
+				order = append(order, 2)
+				return "b", nil
+			},
+			func(ctx context.Context) (string, error) {
+				order = append(order, 3)
+				return "c", nil
+			},
+		)
+		assert.NoError(nt, resultsErr)
+		assert.Equal(nt, results, []string{"a", "b", "c"})
+		assert.Equal(nt, order, []int{1, 2, 3})
+	})
+	t.Run("should return partial results on error", func(nt *testing.T) {
+		called := false
+		results, resultsErr := control.Series(
+			func(ctx context.Context) (int, error) {
+				return 1, nil
+			},
+			func(ctx context.Context) (int, error) {
+				return 0, errors.New("some error")
+			},
+			func(ctx context.Context) (int, error) {
+				called = true
+				return 3, nil
+			},
+		)
+		assert.EqualError(nt, resultsErr, "some error")
+		assert.Equal(nt, results, []int{1})
+		assert.False(nt, called)
+	})
+	t.Run("should return empty results without executors", func(nt *testing.T) {
+		results, resultsErr := control.Series[int]()
+		assert.NoError(nt, resultsErr)
+		assert.Empty(nt, results)
+	})
+}
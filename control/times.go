@@ -0,0 +1,67 @@
+package control
+
+import (
+	"sync"
+)
+
+type timesResult[T any] struct {
+	idx   int
+	value T
+	err   error
+}
+
+// Times calls fn n times concurrently with indices 0 to n-1, and returns results in index order.
+// On the first error or panic pending calls are skipped, and the function returns nil with the error.
+func Times[T any](n int, fn func(i int) (T, error)) ([]T, error) {
+	return TimesLimit(n, fn, n)
+}
+
+// TimesLimit is same as Times, but runs at most limit calls of fn at a time. limit below one is treated as one.
+func TimesLimit[T any](n int, fn func(i int) (T, error), limit int) ([]T, error) {
+	if n <= 0 {
+		return []T{}, nil
+	}
+	if limit < 1 {
+		limit = 1
+	}
+	result := make([]T, n)
+	resultChan := make(chan timesResult[T], n)
+	stop := make(chan struct{})
+	stopOnce := sync.Once{}
+	wg := sync.WaitGroup{}
+	guard := make(chan struct{}, limit)
+	for idx := 0; idx < n; idx++ {
+		select {
+		case <-stop:
+		case guard <- struct{}{}:
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				defer func() { <-guard }()
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				res := timesResult[T]{idx: i}
+				res.err = callSafe(func() (err error) {
+					res.value, err = fn(i)
+					return
+				})
+				if res.err != nil {
+					stopOnce.Do(func() { close(stop) })
+				}
+				resultChan <- res
+			}(idx)
+		}
+	}
+	wg.Wait()
+	close(resultChan)
+	for res := range resultChan {
+		if res.err != nil {
+			return nil, res.err
+		}
+		result[res.idx] = res.value
+	}
+	return result, nil
+}
@@ -0,0 +1,70 @@
+package control_test
+
+import (
+	"errors"
+	"math/rand"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/skatiyar/goutils/control"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimes(t *testing.T) {
+	t.Run("should return results in index order", func(nt *testing.T) {
+		result, resultErr := control.Times(5, func(i int) (int, error) {
+			time.Sleep(time.Duration(rand.Intn(100)) * time.Millisecond)
+			return i * i, nil
+		})
+		assert.NoError(nt, resultErr)
+		assert.Equal(nt, result, []int{0, 1, 4, 9, 16})
+	})
+	t.Run("should return error", func(nt *testing.T) {
+		result, resultErr := control.Times(5, func(i int) (int, error) {
+			if i == 2 {
+				return 0, errors.New("some error")
+			}
+			return i, nil
+		})
+		assert.EqualError(nt, resultErr, "some error")
+		assert.Nil(nt, result)
+	})
+	t.Run("should return panic as error and skip pending calls", func(nt *testing.T) {
+		var calls int32
+		result, resultErr := control.TimesLimit(20, func(i int) (int, error) {
+			atomic.AddInt32(&calls, 1)
+			if i == 1 {
+				panic("boom")
+			}
+			return i, nil
+		}, 1)
+		assert.EqualError(nt, resultErr, "panic in function: boom")
+		assert.Nil(nt, result)
+		assert.Less(nt, atomic.LoadInt32(&calls), int32(20))
+	})
+	t.Run("should return empty slice for zero n", func(nt *testing.T) {
+		result, resultErr := control.Times(0, func(i int) (int, error) {
+			return i, nil
+		})
+		assert.NoError(nt, resultErr)
+		assert.Equal(nt, result, []int{})
+	})
+}
+
+func TestTimesLimit(t *testing.T) {
+	t.Run("should respect limit", func(nt *testing.T) {
+		var running, limitExceeded int32
+		result, resultErr := control.TimesLimit(8, func(i int) (int, error) {
+			if atomic.AddInt32(&running, 1) > 3 {
+				atomic.StoreInt32(&limitExceeded, 1)
+			}
+			time.Sleep(time.Duration(rand.Intn(20)) * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			return i, nil
+		}, 3)
+		assert.NoError(nt, resultErr)
+		assert.Equal(nt, result, []int{0, 1, 2, 3, 4, 5, 6, 7})
+		assert.Equal(nt, atomic.LoadInt32(&limitExceeded), int32(0))
+	})
+}
@@ -7,6 +7,7 @@ import (
 // Waterfall runs the executors in series, each passing their results to the next through context.
 // However, if any of the tasks returns an error, the next task is not executed,
 // and the function immediately returns with the error.
+// For pipelines passing a single value, WaterfallValue is preferred as it is type safe.
 func Waterfall(executors ...func(context.Context) (context.Context, error)) (context.Context, error) {
 	ctx := context.Background()
 	for idx := range executors {
@@ -20,6 +21,21 @@ func Waterfall(executors ...func(context.Context) (context.Context, error)) (con
 	return ctx, nil
 }
 
+// WaterfallValue runs the steps in series, each receiving the typed value returned by the previous step,
+// starting with initial. If any step returns an error, the next step is not executed,
+// and the function immediately returns the last successful value with the error.
+func WaterfallValue[T any](initial T, steps ...func(context.Context, T) (T, error)) (T, error) {
+	ctx := context.Background()
+	for idx := range steps {
+		if value, valueErr := steps[idx](ctx, initial); valueErr != nil {
+			return initial, valueErr
+		} else {
+			initial = value
+		}
+	}
+	return initial, nil
+}
+
 // WaterfallBaseValue returns a function that when called, returns context with the values provided.
 // Useful as the first function in a waterfall.
 func WaterfallBaseValue(key, value interface{}) func(context.Context) (context.Context, error) {
@@ -27,3 +43,50 @@ func WaterfallBaseValue(key, value interface{}) func(context.Context) (context.C
 		return context.WithValue(context.Background(), ContextKey(key), value), nil
 	}
 }
+
+// WaterfallEach runs step once per element of collection in order, threading context from each step to the next,
+// starting with ctx. It is a waterfall driven by data rather than a fixed list of executors.
+// If any step returns an error, remaining elements are not processed,
+// and the function immediately returns the last successful context with the error.
+func WaterfallEach[A any](ctx context.Context, collection []A, step func(context.Context, A) (context.Context, error)) (context.Context, error) {
+	for idx := range collection {
+		stepCtx, stepErr := step(ctx, collection[idx])
+		if stepErr != nil {
+			return ctx, stepErr
+		} else {
+			ctx = stepCtx
+		}
+	}
+	return ctx, nil
+}
+
+// RollbackStep is a step of WaterfallWithRollback. Do performs the step, and Undo compensates it
+// if a later step fails. Undo may be nil for steps which need no compensation.
+type RollbackStep struct {
+	Do   func(context.Context) (context.Context, error)
+	Undo func(context.Context)
+}
+
+// WaterfallWithRollback runs the steps in series like Waterfall, each passing its context to the next.
+// If any step returns an error, the next step is not executed, and Undo of every already completed step
+// is called in reverse order with the context returned by its Do, following the saga pattern.
+// The function then returns the context of the last completed step with the error.
+func WaterfallWithRollback(steps ...RollbackStep) (context.Context, error) {
+	ctx := context.Background()
+	completed := make([]context.Context, 0, len(steps))
+	for idx := range steps {
+		stepCtx, stepErr := steps[idx].Do(ctx)
+		if stepErr != nil {
+			for cidx := len(completed) - 1; cidx >= 0; cidx -= 1 {
+				if steps[cidx].Undo != nil {
+					steps[cidx].Undo(completed[cidx])
+				}
+			}
+			return ctx, stepErr
+		} else {
+			ctx = stepCtx
+			completed = append(completed, stepCtx)
+		}
+	}
+	return ctx, nil
+}
@@ -43,3 +43,129 @@ func TestWaterfall(t *testing.T) {
 		assert.Equal(nt, value, "Hello")
 	})
 }
+
+func TestWaterfallValue(t *testing.T) {
+	t.Run("should thread typed value through steps", func(nt *testing.T) {
+		value, valueErr := control.WaterfallValue("Hello",
+			func(ctx context.Context, val string) (string, error) {
+				return val + " World", nil
+			},
+			func(ctx context.Context, val string) (string, error) {
+				return val + "!", nil
+			},
+		)
+		assert.NoError(nt, valueErr)
+		assert.Equal(nt, value, "Hello World!")
+	})
+	t.Run("should return last successful value when error is returned", func(nt *testing.T) {
+		called := false
+		value, valueErr := control.WaterfallValue(1,
+			func(ctx context.Context, val int) (int, error) {
+				return val + 1, nil
+			},
+			func(ctx context.Context, val int) (int, error) {
+				return 0, errors.New("some error")
+			},
+			func(ctx context.Context, val int) (int, error) {
+				called = true
+				return val + 1, nil
+			},
+		)
+		assert.EqualError(nt, valueErr, "some error")
+		assert.Equal(nt, value, 2)
+		assert.False(nt, called)
+	})
+	t.Run("should return initial value without steps", func(nt *testing.T) {
+		value, valueErr := control.WaterfallValue(5)
+		assert.NoError(nt, valueErr)
+		assert.Equal(nt, value, 5)
+	})
+}
+
+func TestWaterfallEach(t *testing.T) {
+	t.Run("should return accumulated value when no error is returned", func(nt *testing.T) {
+		ctx := control.SetControlContextValue(context.Background(), "Sum", 0)
+		fctx, fctxErr := control.WaterfallEach(ctx, []int{2, 7, 8, 9}, func(ctx context.Context, val int) (context.Context, error) {
+			sum, sumErr := control.GetControlContextValue[string, int](ctx, "Sum")
+			if sumErr != nil {
+				return ctx, sumErr
+			}
+			return control.SetControlContextValue(ctx, "Sum", sum+val), nil
+		})
+		assert.NoError(nt, fctxErr)
+		value, valueErr := control.GetControlContextValue[string, int](fctx, "Sum")
+		assert.NoError(nt, valueErr)
+		assert.Equal(nt, value, 26)
+	})
+	t.Run("should return last successful context when error is returned", func(nt *testing.T) {
+		ctx := control.SetControlContextValue(context.Background(), "Sum", 0)
+		calls := 0
+		fctx, fctxErr := control.WaterfallEach(ctx, []int{2, 7, 8, 9}, func(ctx context.Context, val int) (context.Context, error) {
+			calls += 1
+			if val == 8 {
+				return ctx, errors.New("some error")
+			}
+			sum, _ := control.GetControlContextValue[string, int](ctx, "Sum")
+			return control.SetControlContextValue(ctx, "Sum", sum+val), nil
+		})
+		assert.Error(nt, fctxErr)
+		assert.Equal(nt, calls, 3)
+		value, valueErr := control.GetControlContextValue[string, int](fctx, "Sum")
+		assert.NoError(nt, valueErr)
+		assert.Equal(nt, value, 9)
+	})
+}
+
+func TestWaterfallWithRollback(t *testing.T) {
+	t.Run("should not undo any step when no error is returned", func(nt *testing.T) {
+		undone := make([]string, 0)
+		fctx, fctxErr := control.WaterfallWithRollback(
+			control.RollbackStep{
+				Do: control.WaterfallBaseValue("First", "Hello"),
+				Undo: func(ctx context.Context) {
+					undone = append(undone, "First")
+				},
+			},
+			control.RollbackStep{
+				Do: func(ctx context.Context) (context.Context, error) {
+					return control.SetControlContextValue(ctx, "Second", "World"), nil
+				},
+			},
+		)
+		assert.NoError(nt, fctxErr)
+		assert.Empty(nt, undone)
+		value, valueErr := control.GetControlContextValue[string, string](fctx, "Second")
+		assert.NoError(nt, valueErr)
+		assert.Equal(nt, value, "World")
+	})
+	t.Run("should undo completed steps in reverse order when error is returned", func(nt *testing.T) {
+		undone := make([]string, 0)
+		undo := func(ctx context.Context) {
+			value, _ := control.GetControlContextValue[string, string](ctx, "Step")
+			undone = append(undone, value)
+		}
+		fctx, fctxErr := control.WaterfallWithRollback(
+			control.RollbackStep{
+				Do:   control.WaterfallBaseValue("Step", "First"),
+				Undo: undo,
+			},
+			control.RollbackStep{
+				Do: func(ctx context.Context) (context.Context, error) {
+					return control.SetControlContextValue(ctx, "Step", "Second"), nil
+				},
+				Undo: undo,
+			},
+			control.RollbackStep{
+				Do: func(ctx context.Context) (context.Context, error) {
+					return ctx, errors.New("some error")
+				},
+				Undo: undo,
+			},
+		)
+		assert.Error(nt, fctxErr)
+		assert.Equal(nt, undone, []string{"Second", "First"})
+		value, valueErr := control.GetControlContextValue[string, string](fctx, "Step")
+		assert.NoError(nt, valueErr)
+		assert.Equal(nt, value, "Second")
+	})
+}
@@ -0,0 +1,27 @@
+package control
+
+import (
+	"context"
+)
+
+// Whilst repeatedly calls fn while test returns true. test is checked before each call,
+// so fn is never called if test returns false immediately.
+// If fn returns an error or ctx is cancelled, the loop stops and the function returns with the error.
+func Whilst(ctx context.Context, test func() bool, fn func(context.Context) error) error {
+	for {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if !test() {
+			return nil
+		}
+		if err := fn(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+// Until is the opposite of Whilst. It repeatedly calls fn until test returns true.
+func Until(ctx context.Context, test func() bool, fn func(context.Context) error) error {
+	return Whilst(ctx, func() bool { return !test() }, fn)
+}
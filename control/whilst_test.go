@@ -0,0 +1,87 @@
+package control_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/skatiyar/goutils/control"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWhilst(t *testing.T) {
+	t.Run("should not call fn if test is false", func(nt *testing.T) {
+		calls := 0
+		err := control.Whilst(context.Background(), func() bool { return false }, func(ctx context.Context) error {
+			calls += 1
+			return nil
+		})
+		assert.NoError(nt, err)
+		assert.Equal(nt, calls, 0)
+	})
+	t.Run("should loop while test is true", func(nt *testing.T) {
+		count := 0
+		err := control.Whilst(context.Background(), func() bool { return count < 5 }, func(ctx context.Context) error {
+			count += 1
+			return nil
+		})
+		assert.NoError(nt, err)
+		assert.Equal(nt, count, 5)
+	})
+	t.Run("should return error of fn", func(nt *testing.T) {
+		count := 0
+		err := control.Whilst(context.Background(), func() bool { return true }, func(ctx context.Context) error {
+			count += 1
+			if count == 3 {
+				return errors.New("some error")
+			}
+			return nil
+		})
+		assert.EqualError(nt, err, "some error")
+		assert.Equal(nt, count, 3)
+	})
+	t.Run("should stop when context is cancelled", func(nt *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		err := control.Whilst(ctx, func() bool { return true }, func(ctx context.Context) error {
+			time.Sleep(10 * time.Millisecond)
+			return nil
+		})
+		assert.ErrorIs(nt, err, context.DeadlineExceeded)
+	})
+}
+
+func TestUntil(t *testing.T) {
+	t.Run("should not call fn if test is true", func(nt *testing.T) {
+		calls := 0
+		err := control.Until(context.Background(), func() bool { return true }, func(ctx context.Context) error {
+			calls += 1
+			return nil
+		})
+		assert.NoError(nt, err)
+		assert.Equal(nt, calls, 0)
+	})
+	t.Run("should loop until test is true", func(nt *testing.T) {
+		count := 0
+		err := control.Until(context.Background(), func() bool { return count == 4 }, func(ctx context.Context) error {
+			count += 1
+			return nil
+		})
+		assert.NoError(nt, err)
+		assert.Equal(nt, count, 4)
+	})
+	t.Run("should stop when context is cancelled", func(nt *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		count := 0
+		err := control.Until(ctx, func() bool { return false }, func(ctx context.Context) error {
+			count += 1
+			if count == 2 {
+				cancel()
+			}
+			return nil
+		})
+		assert.ErrorIs(nt, err, context.Canceled)
+		assert.Equal(nt, count, 2)
+	})
+}
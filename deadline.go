@@ -0,0 +1,43 @@
+package goutils
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+type deadlineResult[T any] struct {
+	value T
+	err   error
+}
+
+// WithDeadline runs fn in a separate goroutine and returns its result, or context.DeadlineExceeded
+// if fn does not return within d. It bounds otherwise uncancellable synchronous functions like ReduceSlice.
+// A panic in fn is recovered and returned as an error. On timeout fn is not stopped
+// and keeps running in the background, with its result discarded.
+func WithDeadline[T any](d time.Duration, fn func() (T, error)) (T, error) {
+	resultChan := make(chan deadlineResult[T], 1)
+	go func() {
+		result := deadlineResult[T]{}
+		defer func() {
+			if r := recover(); r != nil {
+				if rerr, ok := r.(error); ok {
+					result.err = rerr
+				} else {
+					result.err = fmt.Errorf("panic in function: %v", r)
+				}
+			}
+			resultChan <- result
+		}()
+		result.value, result.err = fn()
+	}()
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case result := <-resultChan:
+		return result.value, result.err
+	case <-timer.C:
+		var zero T
+		return zero, context.DeadlineExceeded
+	}
+}
@@ -0,0 +1,53 @@
+package goutils_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/skatiyar/goutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithDeadline(t *testing.T) {
+	collection := []int{1, 2, 3, 4}
+	t.Run("should return result when function finishes in time", func(nt *testing.T) {
+		result, resultErr := goutils.WithDeadline(time.Second, func() (int, error) {
+			return goutils.ReduceSlice(collection, func(acc int, value int, idx int) (int, error) {
+				return acc + value, nil
+			}, 0)
+		})
+		assert.NoError(nt, resultErr)
+		assert.Equal(nt, result, 10)
+	})
+	t.Run("should return error of function", func(nt *testing.T) {
+		_, resultErr := goutils.WithDeadline(time.Second, func() (int, error) {
+			return goutils.ReduceSlice(collection, func(acc int, value int, idx int) (int, error) {
+				return acc, errors.New("some error")
+			}, 0)
+		})
+		assert.EqualError(nt, resultErr, "some error")
+	})
+	t.Run("should return deadline exceeded for slow function", func(nt *testing.T) {
+		start := time.Now()
+		result, resultErr := goutils.WithDeadline(50*time.Millisecond, func() (int, error) {
+			return goutils.ReduceSlice(collection, func(acc int, value int, idx int) (int, error) {
+				time.Sleep(50 * time.Millisecond)
+				return acc + value, nil
+			}, 0)
+		})
+		assert.ErrorIs(nt, resultErr, context.DeadlineExceeded)
+		assert.Equal(nt, result, 0)
+		assert.Less(nt, time.Since(start), 150*time.Millisecond)
+	})
+	t.Run("should return panic as error", func(nt *testing.T) {
+		result, resultErr := goutils.WithDeadline(time.Second, func() (int, error) {
+			return goutils.ReduceSlice(collection, func(acc int, value int, idx int) (int, error) {
+				panic("boom")
+			}, 0)
+		})
+		assert.EqualError(nt, resultErr, "panic in function: boom")
+		assert.Equal(nt, result, 0)
+	})
+}
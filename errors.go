@@ -0,0 +1,54 @@
+package goutils
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrLengthMismatch    = errors.New("collections have different lengths")
+	ErrIndexOutOfRange   = errors.New("index out of range")
+	ErrInvalidSampleSize = errors.New("sample size must be between zero and length of collection")
+	ErrNegativeWeight    = errors.New("weight must not be negative")
+	ErrInvalidBatchSize  = errors.New("batch size must be greater than zero")
+)
+
+// MultiError aggregates multiple errors into a single error.
+// It is returned by functions which do not short-circuit on the first error.
+type MultiError []error
+
+// Error returns the messages of all aggregated errors joined by "; ".
+func (me MultiError) Error() string {
+	messages := make([]string, 0, len(me))
+	for _, err := range me {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Unwrap returns the aggregated errors, allowing errors.Is and errors.As to inspect them on Go 1.20 and later.
+func (me MultiError) Unwrap() []error {
+	return me
+}
+
+// Is reports whether any aggregated error matches target, so errors.Is inspects members on Go versions
+// which do not follow Unwrap() []error.
+func (me MultiError) Is(target error) bool {
+	for _, err := range me {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first aggregated error which matches target, and if one is found, sets target to it.
+// It allows errors.As to inspect members on Go versions which do not follow Unwrap() []error.
+func (me MultiError) As(target any) bool {
+	for _, err := range me {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
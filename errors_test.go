@@ -0,0 +1,41 @@
+package goutils_test
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"strconv"
+	"testing"
+
+	"github.com/skatiyar/goutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiError(t *testing.T) {
+	t.Run("should join error messages", func(nt *testing.T) {
+		err := goutils.MultiError{errors.New("first error"), errors.New("second error")}
+		assert.EqualError(nt, err, "first error; second error")
+	})
+	t.Run("should unwrap aggregated errors", func(nt *testing.T) {
+		errFirst, errSecond := errors.New("first error"), errors.New("second error")
+		err := goutils.MultiError{errFirst, errSecond}
+		assert.Equal(nt, err.Unwrap(), []error{errFirst, errSecond})
+	})
+	t.Run("should match aggregated errors with errors.Is", func(nt *testing.T) {
+		errFirst, errSecond := errors.New("first error"), errors.New("second error")
+		err := error(goutils.MultiError{errFirst, fmt.Errorf("wrapped: %w", errSecond)})
+		assert.True(nt, errors.Is(err, errFirst))
+		assert.True(nt, errors.Is(err, errSecond))
+		assert.False(nt, errors.Is(err, errors.New("first error")))
+	})
+	t.Run("should find aggregated errors with errors.As", func(nt *testing.T) {
+		pathErr := &fs.PathError{Op: "open", Path: "file", Err: fs.ErrNotExist}
+		err := error(goutils.MultiError{errors.New("first error"), pathErr})
+		var target *fs.PathError
+		assert.True(nt, errors.As(err, &target))
+		assert.Equal(nt, target, pathErr)
+		assert.True(nt, errors.Is(err, fs.ErrNotExist))
+		var missing *strconv.NumError
+		assert.False(nt, errors.As(err, &missing))
+	})
+}
@@ -1,5 +1,17 @@
 package goutils
 
+import (
+	"sort"
+
+	"github.com/skatiyar/goutils/constraints"
+)
+
+// Entry is a single key and value pair of a map.
+type Entry[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
 // ConcatMap applies iteratee to each item in collection, concatenating the results and returns the concatenated list.
 // The results array will be unorder as map iterations are unordered.
 // If iterator returns an error, function returns immediately with an error and result as nil.
@@ -32,6 +44,16 @@ func DetectMap[A comparable, B any](collection map[A]B, fn func(key A, value B)
 	return
 }
 
+// sortedKeys returns keys of collection in ascending order.
+func sortedKeys[A constraints.Ordered, B any](collection map[A]B) []A {
+	keys := make([]A, 0, len(collection))
+	for key := range collection {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
+
 // EachMap applies the function iteratee to each item in collection.
 // The iteratee is called with an item from the collection.
 // If the iterator returns an error, function returns immediately with an error.
@@ -44,6 +66,17 @@ func EachMap[A comparable, B any](collection map[A]B, fn func(key A, value B) er
 	return nil
 }
 
+// EachOrderedMap applies the function iteratee to each item in collection, in ascending key order.
+// If the iterator returns an error, function returns immediately with an error.
+func EachOrderedMap[A constraints.Ordered, B any](collection map[A]B, fn func(key A, value B) error) error {
+	for _, key := range sortedKeys(collection) {
+		if err := fn(key, collection[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Map produces a new collection by mapping each key and value in collection through the iteratee function.
 // The iteratee is called with key and value from collection, returns new key and value.
 // If the iterator returns an error, function returns immediately with an error.
@@ -59,6 +92,36 @@ func Map[A comparable, X comparable, B any, Z any](collection map[A]B, fn func(k
 	return result, nil
 }
 
+// EachMapUniqueValues applies the function iteratee once to each distinct value in collection, ignoring keys.
+// If the iterator returns an error, function returns immediately with an error.
+func EachMapUniqueValues[K comparable, V comparable](collection map[K]V, fn func(value V) error) error {
+	seen := make(map[V]struct{})
+	for _, value := range collection {
+		if _, ok := seen[value]; ok {
+			continue
+		}
+		seen[value] = struct{}{}
+		if err := fn(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MapPartitioned applies iteratee to every entry in collection without short-circuiting on error.
+// Successful results are returned in success, and errors in failed, both keyed by the key of the source entry.
+func MapPartitioned[A comparable, B any, Z any](collection map[A]B, fn func(key A, value B) (Z, error)) (success map[A]Z, failed map[A]error) {
+	success, failed = make(map[A]Z), make(map[A]error)
+	for key, val := range collection {
+		if rv, re := fn(key, val); re != nil {
+			failed[key] = re
+		} else {
+			success[key] = rv
+		}
+	}
+	return success, failed
+}
+
 // ReduceMap reduces collection into a single value using an iteratee to return each successive step.
 // If the iterator returns an error, function returns immediately with an error.
 func ReduceMap[A comparable, B any, X any](collection map[A]B, fn func(accumulator X, key A, value B) (X, error), initial X) (X, error) {
@@ -72,6 +135,29 @@ func ReduceMap[A comparable, B any, X any](collection map[A]B, fn func(accumulat
 	return initial, nil
 }
 
+// ReduceRightMap reduces collection into a single value, visiting entries in descending key order.
+// If the iterator returns an error, function returns immediately with an error.
+func ReduceRightMap[A constraints.Ordered, B any, X any](collection map[A]B, fn func(accumulator X, key A, value B) (X, error), initial X) (X, error) {
+	keys := sortedKeys(collection)
+	for idx := len(keys) - 1; idx >= 0; idx -= 1 {
+		if acc, accErr := fn(initial, keys[idx], collection[keys[idx]]); accErr != nil {
+			return initial, accErr
+		} else {
+			initial = acc
+		}
+	}
+	return initial, nil
+}
+
+// FoldMap reduces collection into a single value using an iteratee which never fails.
+// It is a simpler form of ReduceMap for pure reductions.
+func FoldMap[A comparable, B any, X any](collection map[A]B, fn func(accumulator X, key A, value B) X, initial X) X {
+	for key, value := range collection {
+		initial = fn(initial, key, value)
+	}
+	return initial
+}
+
 // EveryMap returns true if every element in collection satisfies a test.
 // If any iteratee call returns false or an error, function returns immediately.
 func EveryMap[A comparable, B any](collection map[A]B, fn func(key A, value B) (bool, error)) (bool, error) {
@@ -99,6 +185,14 @@ func FilterMap[A comparable, B any](collection map[A]B, fn func(key A, value B)
 	return result, nil
 }
 
+// FilterMapEntries is same as FilterMap, but the truth test receives each key and value together as an Entry.
+// If the iterator returns an error, function returns immediately with an error.
+func FilterMapEntries[K comparable, V any](collection map[K]V, fn func(entry Entry[K, V]) (bool, error)) (map[K]V, error) {
+	return FilterMap(collection, func(key K, value V) (bool, error) {
+		return fn(Entry[K, V]{Key: key, Value: value})
+	})
+}
+
 // GroupByMap returns a new map, where each value corresponds to an array of items, from collection, that returned the corresponding key.
 // That is, the keys of the object correspond to the values passed to the iteratee callback.
 // If the iterator returns an error, function returns immediately with an error.
@@ -142,3 +236,49 @@ func SomeMap[A comparable, B any](collection map[A]B, fn func(key A, value B) (b
 	}
 	return false, nil
 }
+
+// FlattenMapEntries returns a flat slice of entries, one per member of each slice in collection.
+// It is the inverse of GroupByMap and GroupBySlice. Entries of a key keep the order of its slice,
+// but keys are unordered as map iterations are unordered.
+func FlattenMapEntries[K comparable, V any](collection map[K][]V) []Entry[K, V] {
+	result := make([]Entry[K, V], 0)
+	for key, values := range collection {
+		for _, value := range values {
+			result = append(result, Entry[K, V]{Key: key, Value: value})
+		}
+	}
+	return result
+}
+
+// ZipMap returns a new map built from parallel slices of keys and values, where keys[i] maps to values[i].
+// If a key repeats, the value paired with its last occurrence is kept.
+// If keys and values have different lengths, function returns with ErrLengthMismatch.
+func ZipMap[K comparable, V any](keys []K, values []V) (map[K]V, error) {
+	if len(keys) != len(values) {
+		return nil, ErrLengthMismatch
+	}
+	result := make(map[K]V, len(keys))
+	for idx, key := range keys {
+		result[key] = values[idx]
+	}
+	return result, nil
+}
+
+// EachPair applies the function iteratee to each unordered pair of distinct entries in collection,
+// that is n(n-1)/2 calls for a collection of n entries, making it O(n²).
+// Pairs are visited in no particular order as map iterations are unordered.
+// If the iterator returns an error, function returns immediately with an error.
+func EachPair[K comparable, V any](collection map[K]V, fn func(a, b Entry[K, V]) error) error {
+	entries := make([]Entry[K, V], 0, len(collection))
+	for key, value := range collection {
+		entries = append(entries, Entry[K, V]{Key: key, Value: value})
+	}
+	for i := 0; i < len(entries); i++ {
+		for j := i + 1; j < len(entries); j++ {
+			if err := fn(entries[i], entries[j]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
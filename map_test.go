@@ -80,6 +80,56 @@ func TestEachMap(t *testing.T) {
 	})
 }
 
+func TestEachMapUniqueValues(t *testing.T) {
+	t.Run("should call iterator once per distinct value", func(nt *testing.T) {
+		collection := map[string]string{"1": "fox", "2": "fence", "3": "fox", "4": "dog", "5": "fence"}
+		calls := make(map[string]int)
+		err := goutils.EachMapUniqueValues(collection, func(val string) error {
+			calls[val] += 1
+			return nil
+		})
+		assert.NoError(nt, err)
+		assert.Equal(nt, calls, map[string]int{"fox": 1, "fence": 1, "dog": 1})
+	})
+	t.Run("should return error when iterator returns error", func(nt *testing.T) {
+		collection := map[string]string{"1": "fox", "2": "fence", "3": "fox"}
+		calls := 0
+		err := goutils.EachMapUniqueValues(collection, func(val string) error {
+			calls += 1
+			return errors.New("an error")
+		})
+		assert.Error(nt, err)
+		assert.Equal(nt, calls, 1)
+	})
+}
+
+func TestEachOrderedMap(t *testing.T) {
+	t.Run("should call iterator in ascending key order", func(nt *testing.T) {
+		collection := map[string]string{"3": "jumps over the", "1": "the brown", "4": "brown fence", "2": "fox"}
+		keys := make([]string, 0)
+		err := goutils.EachOrderedMap(collection, func(key, val string) error {
+			assert.Equal(nt, collection[key], val)
+			keys = append(keys, key)
+			return nil
+		})
+		assert.NoError(nt, err)
+		assert.Equal(nt, keys, []string{"1", "2", "3", "4"})
+	})
+	t.Run("should pass when iterator returns error", func(nt *testing.T) {
+		collection := map[int]string{3: "jumps over the", 1: "the brown", 4: "brown fence", 2: "fox"}
+		keys := make([]int, 0)
+		err := goutils.EachOrderedMap(collection, func(key int, val string) error {
+			keys = append(keys, key)
+			if key == 2 {
+				return errors.New("an error")
+			}
+			return nil
+		})
+		assert.Error(nt, err)
+		assert.Equal(nt, keys, []int{1, 2})
+	})
+}
+
 func TestMap(t *testing.T) {
 	t.Run("should return correct values when iterator returns no error", func(nt *testing.T) {
 		collection := map[string]string{"1": "the brown", "2": "fox", "3": "jumps over the", "4": "brown fence"}
@@ -100,6 +150,28 @@ func TestMap(t *testing.T) {
 	})
 }
 
+func TestMapPartitioned(t *testing.T) {
+	t.Run("should return successes and failures keyed by source key", func(nt *testing.T) {
+		collection := map[string]string{"1": "the brown", "2": "fox", "3": "jumps over the", "4": "brown fence"}
+		errShort := errors.New("value too short")
+		success, failed := goutils.MapPartitioned(collection, func(key, val string) (int, error) {
+			if len(val) < 10 {
+				return 0, errShort
+			}
+			return len(val), nil
+		})
+		assert.Equal(nt, success, map[string]int{"3": 14, "4": 11})
+		assert.Equal(nt, failed, map[string]error{"1": errShort, "2": errShort})
+	})
+	t.Run("should return empty failures when iterator returns no error", func(nt *testing.T) {
+		success, failed := goutils.MapPartitioned(map[string]int{"1": 1, "2": 2}, func(key string, val int) (int, error) {
+			return val * 2, nil
+		})
+		assert.Equal(nt, success, map[string]int{"1": 2, "2": 4})
+		assert.Empty(nt, failed)
+	})
+}
+
 func TestReduceMap(t *testing.T) {
 	t.Run("should return correct values when iterator returns no error", func(nt *testing.T) {
 		collection := map[string]string{"1": "the brown", "2": "fox", "3": "jumps over the", "4": "brown fence"}
@@ -120,6 +192,60 @@ func TestReduceMap(t *testing.T) {
 	})
 }
 
+func TestReduceRightMap(t *testing.T) {
+	t.Run("should return correct values when iterator returns no error", func(nt *testing.T) {
+		collection := map[int]string{3: "jumps over the", 1: "the brown", 4: "brown fence", 2: "fox"}
+		collectionResult := "brown fence jumps over the fox the brown"
+		reduced, reducedErr := goutils.ReduceRightMap(collection, func(acc string, key int, val string) (string, error) {
+			if len(acc) > 0 {
+				return acc + " " + val, nil
+			} else {
+				return val, nil
+			}
+		}, "")
+		assert.NoError(nt, reducedErr)
+		assert.Equal(nt, reduced, collectionResult)
+	})
+	t.Run("should return reverse of ascending fold for non-commutative iterator", func(nt *testing.T) {
+		collection := map[int]string{3: "c", 1: "a", 4: "d", 2: "b"}
+		ascending, ascendingErr := goutils.ReduceSlice([]int{1, 2, 3, 4}, func(acc string, key int, idx int) (string, error) {
+			return acc + collection[key], nil
+		}, "")
+		assert.NoError(nt, ascendingErr)
+		descending, descendingErr := goutils.ReduceRightMap(collection, func(acc string, key int, val string) (string, error) {
+			return acc + val, nil
+		}, "")
+		assert.NoError(nt, descendingErr)
+		assert.Equal(nt, ascending, "abcd")
+		assert.Equal(nt, descending, "dcba")
+	})
+	t.Run("should return correct values when iterator returns error", func(nt *testing.T) {
+		collection := map[int]string{3: "jumps over the", 1: "the brown", 4: "brown fence", 2: "fox"}
+		reduced, reducedErr := goutils.ReduceRightMap(collection, func(acc string, key int, val string) (string, error) {
+			return acc + " " + val, errors.New("an error")
+		}, "")
+		assert.Error(nt, reducedErr)
+		assert.Empty(nt, reduced)
+	})
+}
+
+func TestFoldMap(t *testing.T) {
+	t.Run("should return sum of values", func(nt *testing.T) {
+		collection := map[string]int{"1": 2, "2": 7, "3": 8, "4": 9}
+		assert.Equal(nt, goutils.FoldMap(collection, func(acc int, key string, val int) int {
+			return acc + val
+		}, 0), 26)
+	})
+	t.Run("should return concatenated values", func(nt *testing.T) {
+		collection := map[string]string{"1": "the brown", "2": "fox", "3": "jumps over the", "4": "brown fence"}
+		collectionResult := []string{"the", "brown", "fox", "jumps", "over", "the", "brown", "fence"}
+		folded := goutils.FoldMap(collection, func(acc []string, key, val string) []string {
+			return append(acc, strings.Split(val, " ")...)
+		}, []string{})
+		assert.ElementsMatch(nt, folded, collectionResult)
+	})
+}
+
 func TestEveryMap(t *testing.T) {
 	t.Run("should return correct values when iterator returns no error and all values pass test", func(nt *testing.T) {
 		collection := map[string]string{"1": "the brown", "2": "fox", "3": "jumps over the", "4": "brown fence"}
@@ -166,6 +292,25 @@ func TestFilterMap(t *testing.T) {
 	})
 }
 
+func TestFilterMapEntries(t *testing.T) {
+	t.Run("should keep entries passing truth test", func(nt *testing.T) {
+		collection := map[int]int{1: 5, 4: 2, 3: 3, 2: 9, 7: 1}
+		filtered, filteredErr := goutils.FilterMapEntries(collection, func(entry goutils.Entry[int, int]) (bool, error) {
+			return entry.Key < entry.Value, nil
+		})
+		assert.NoError(nt, filteredErr)
+		assert.Equal(nt, filtered, map[int]int{1: 5, 2: 9})
+	})
+	t.Run("should return error", func(nt *testing.T) {
+		collection := map[int]int{1: 5, 4: 2}
+		filtered, filteredErr := goutils.FilterMapEntries(collection, func(entry goutils.Entry[int, int]) (bool, error) {
+			return false, errors.New("an error")
+		})
+		assert.Error(nt, filteredErr)
+		assert.Nil(nt, filtered)
+	})
+}
+
 func TestGroupByMap(t *testing.T) {
 	t.Run("should return correct values when iterator returns no error", func(nt *testing.T) {
 		collection := map[string]string{"1": "the brown", "2": "fox", "3": "jumps over the", "4": "brown fence", "5": "fly"}
@@ -234,3 +379,86 @@ func TestSomeMap(t *testing.T) {
 		assert.False(nt, mapped)
 	})
 }
+
+func TestFlattenMapEntries(t *testing.T) {
+	t.Run("should return flattened entries of grouped map", func(nt *testing.T) {
+		collection := map[string]string{"1": "the brown", "2": "fox", "3": "jumps over the", "4": "brown fence", "5": "fly"}
+		grouped, groupedErr := goutils.GroupByMap(collection, func(key, val string) (int, string, error) {
+			return len(val), key, nil
+		})
+		assert.NoError(nt, groupedErr)
+		entries := goutils.FlattenMapEntries(grouped)
+		assert.Len(nt, entries, len(collection))
+		regrouped, regroupedErr := goutils.GroupBySlice(entries, func(entry goutils.Entry[int, string], idx int) (int, string, error) {
+			return entry.Key, entry.Value, nil
+		})
+		assert.NoError(nt, regroupedErr)
+		assert.Len(nt, regrouped, len(grouped))
+		for key, val := range grouped {
+			assert.ElementsMatch(nt, regrouped[key], val)
+		}
+	})
+	t.Run("should preserve order of members within a key", func(nt *testing.T) {
+		entries := goutils.FlattenMapEntries(map[string][]int{"odd": {1, 3, 5}})
+		assert.Equal(nt, entries, []goutils.Entry[string, int]{{Key: "odd", Value: 1}, {Key: "odd", Value: 3}, {Key: "odd", Value: 5}})
+	})
+	t.Run("should return empty slice for empty map", func(nt *testing.T) {
+		assert.Empty(nt, goutils.FlattenMapEntries(map[string][]int{}))
+	})
+}
+
+func TestZipMap(t *testing.T) {
+	t.Run("should return correct values when lengths are equal", func(nt *testing.T) {
+		zipped, zippedErr := goutils.ZipMap([]string{"1", "2", "3"}, []string{"the brown", "fox", "jumps over the"})
+		assert.NoError(nt, zippedErr)
+		assert.Equal(nt, zipped, map[string]string{"1": "the brown", "2": "fox", "3": "jumps over the"})
+	})
+	t.Run("should keep last value for repeated keys", func(nt *testing.T) {
+		zipped, zippedErr := goutils.ZipMap([]string{"1", "2", "1"}, []string{"the brown", "fox", "jumps over the"})
+		assert.NoError(nt, zippedErr)
+		assert.Equal(nt, zipped, map[string]string{"1": "jumps over the", "2": "fox"})
+	})
+	t.Run("should return error when lengths are different", func(nt *testing.T) {
+		zipped, zippedErr := goutils.ZipMap([]string{"1", "2", "3"}, []string{"the brown", "fox"})
+		assert.ErrorIs(nt, zippedErr, goutils.ErrLengthMismatch)
+		assert.Nil(nt, zipped)
+	})
+}
+
+func TestEachPair(t *testing.T) {
+	t.Run("should call iterator once for each unordered pair", func(nt *testing.T) {
+		collection := map[string]string{"1": "the brown", "2": "fox", "3": "jumps over the", "4": "brown fence", "5": "fly"}
+		pairs := make(map[[2]string]int)
+		err := goutils.EachPair(collection, func(a, b goutils.Entry[string, string]) error {
+			assert.NotEqual(nt, a.Key, b.Key)
+			assert.Equal(nt, collection[a.Key], a.Value)
+			assert.Equal(nt, collection[b.Key], b.Value)
+			if a.Key > b.Key {
+				a, b = b, a
+			}
+			pairs[[2]string{a.Key, b.Key}] += 1
+			return nil
+		})
+		assert.NoError(nt, err)
+		assert.Len(nt, pairs, len(collection)*(len(collection)-1)/2)
+		for _, count := range pairs {
+			assert.Equal(nt, count, 1)
+		}
+	})
+	t.Run("should not call iterator for single entry", func(nt *testing.T) {
+		err := goutils.EachPair(map[string]int{"1": 1}, func(a, b goutils.Entry[string, int]) error {
+			return errors.New("an error")
+		})
+		assert.NoError(nt, err)
+	})
+	t.Run("should return error when iterator returns error", func(nt *testing.T) {
+		collection := map[string]string{"1": "the brown", "2": "fox", "3": "jumps over the"}
+		calls := 0
+		err := goutils.EachPair(collection, func(a, b goutils.Entry[string, string]) error {
+			calls += 1
+			return errors.New("an error")
+		})
+		assert.Error(nt, err)
+		assert.Equal(nt, calls, 1)
+	})
+}
@@ -1,62 +1,356 @@
 package queue
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 var (
-	ErrorQueueClosed = "queue has been closed"
+	ErrorQueueClosed    = "queue has been closed"
+	ErrorQueueNotClosed = "queue has not been closed"
+	ErrorQueueDraining  = "queue is still processing tasks"
 )
 
+// throughputSmoothing is the weight given to the latest interval in the moving average of intervals between completions.
+const throughputSmoothing = 0.2
+
 type task[T any] struct {
 	value         T
 	errorCallback func(error)
 }
 
+// Metrics is a snapshot of the counters of a queue.
+type Metrics struct {
+	Processed       int64
+	Succeeded       int64
+	Failed          int64
+	AverageDuration time.Duration
+}
+
 type QueueImpl[T any] struct {
+	// 64-bit fields accessed atomically are kept first for alignment on 32-bit platforms.
+	processed     int64
+	succeeded     int64
+	failed        int64
+	totalDuration int64
+
 	wg          sync.WaitGroup
-	items       chan task[T]
-	worker      func(T) error
+	worker      atomic.Value
 	concurrency int
-	closed      bool
+
+	stateMu    sync.RWMutex
+	items      chan task[T]
+	closed     bool
+	workerDone chan struct{}
+
+	pauseMu       sync.Mutex
+	resumed       chan struct{}
+	pauseRequests chan chan struct{}
+
+	drainMu    sync.Mutex
+	pending    int
+	idle       chan struct{}
+	drainHooks []func()
+
+	completedMu     sync.Mutex
+	completed       int
+	completedSignal chan struct{}
+	lastCompletion  time.Time
+	interval        float64
+	latencies       map[time.Duration]int
 }
 
 func NewQueue[T any](fn func(T) error, concurrency int) *QueueImpl[T] {
 	queue := &QueueImpl[T]{
-		wg:          sync.WaitGroup{},
-		items:       make(chan task[T]),
-		worker:      fn,
-		concurrency: concurrency,
+		wg:              sync.WaitGroup{},
+		items:           make(chan task[T]),
+		concurrency:     concurrency,
+		completedSignal: make(chan struct{}),
+		latencies:       make(map[time.Duration]int),
+		idle:            make(chan struct{}),
+		pauseRequests:   make(chan chan struct{}),
+		workerDone:      make(chan struct{}),
 	}
-	go queue.workers()
+	close(queue.idle)
+	queue.worker.Store(fn)
+	go queue.workers(queue.items, queue.workerDone)
 	return queue
 }
 
-func (qi *QueueImpl[T]) workers() {
+// workers processes tasks from items until it is closed, then closes done.
+// Pause requests are received in the same select as tasks, so no task is taken once a pause is accepted.
+func (qi *QueueImpl[T]) workers(items <-chan task[T], done chan struct{}) {
+	defer close(done)
 	for {
 		select {
-		case val, ok := <-qi.items:
+		case resumed := <-qi.pauseRequests:
+			<-resumed
+		case val, ok := <-items:
 			if !ok {
 				return
 			}
-			if err := qi.worker(val.value); err != nil {
+			worker := qi.worker.Load().(func(T) error)
+			start := time.Now()
+			err := process(worker, val.value)
+			latency := time.Since(start)
+			atomic.AddInt64(&qi.totalDuration, int64(latency))
+			atomic.AddInt64(&qi.processed, 1)
+			if err != nil {
+				atomic.AddInt64(&qi.failed, 1)
 				val.errorCallback(err)
+			} else {
+				atomic.AddInt64(&qi.succeeded, 1)
 			}
-		default:
+			qi.complete(latency)
+			qi.settle()
+		}
+	}
+}
 
+// process calls worker with value, converting a panic into an error so the queue keeps running.
+func process[T any](worker func(T) error, value T) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic in worker: %v", r)
 		}
+	}()
+	return worker(value)
+}
+
+// Metrics returns counters of processed, succeeded and failed tasks, where a task fails if the worker
+// returns an error or panics, along with the average processing duration. Counters are read without locking,
+// so they may be slightly inconsistent with each other while tasks are completing.
+func (qi *QueueImpl[T]) Metrics() Metrics {
+	metrics := Metrics{
+		Processed: atomic.LoadInt64(&qi.processed),
+		Succeeded: atomic.LoadInt64(&qi.succeeded),
+		Failed:    atomic.LoadInt64(&qi.failed),
 	}
+	if metrics.Processed > 0 {
+		metrics.AverageDuration = time.Duration(atomic.LoadInt64(&qi.totalDuration) / metrics.Processed)
+	}
+	return metrics
 }
 
+// complete records completion of a task which took latency to process, updates throughput and latency histogram,
+// and wakes up goroutines waiting on completions.
+func (qi *QueueImpl[T]) complete(latency time.Duration) {
+	qi.completedMu.Lock()
+	defer qi.completedMu.Unlock()
+	now := time.Now()
+	if qi.completed > 0 {
+		if elapsed := now.Sub(qi.lastCompletion).Seconds(); qi.completed == 1 {
+			qi.interval = elapsed
+		} else {
+			qi.interval = throughputSmoothing*elapsed + (1-throughputSmoothing)*qi.interval
+		}
+	}
+	qi.lastCompletion = now
+	qi.latencies[latencyBucket(latency)] += 1
+	qi.completed += 1
+	close(qi.completedSignal)
+	qi.completedSignal = make(chan struct{})
+}
+
+// Throughput returns completed tasks per second, as the inverse of an exponential moving average
+// of intervals between consecutive completions. Once the time since the last completion exceeds
+// the average interval, that time is used instead, so throughput decays towards 0 while the queue is idle.
+// It returns 0 until at least two tasks have completed.
+func (qi *QueueImpl[T]) Throughput() float64 {
+	qi.completedMu.Lock()
+	defer qi.completedMu.Unlock()
+	if qi.completed < 2 {
+		return 0
+	}
+	interval := qi.interval
+	if idle := time.Since(qi.lastCompletion).Seconds(); idle > interval {
+		interval = idle
+	}
+	if interval <= 0 {
+		return 0
+	}
+	return 1 / interval
+}
+
+// settle marks a pushed task as done, and calls drain callbacks if no pushed task is left.
+func (qi *QueueImpl[T]) settle() {
+	qi.drainMu.Lock()
+	qi.pending -= 1
+	var hooks []func()
+	if qi.pending == 0 {
+		close(qi.idle)
+		hooks = append(hooks, qi.drainHooks...)
+	}
+	qi.drainMu.Unlock()
+	for _, hook := range hooks {
+		go hook()
+	}
+}
+
+// Wait blocks until every pushed task has been processed, without closing the queue, or ctx is done.
+// Returns the context error if ctx is done before the queue becomes idle.
+func (qi *QueueImpl[T]) Wait(ctx context.Context) error {
+	qi.drainMu.Lock()
+	idle := qi.idle
+	qi.drainMu.Unlock()
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// OnDrain registers fn to be called every time the queue becomes idle,
+// that is when the last pushed task has been processed and no push is waiting.
+// fn is called on its own goroutine, so it may push new tasks.
+func (qi *QueueImpl[T]) OnDrain(fn func()) {
+	qi.drainMu.Lock()
+	defer qi.drainMu.Unlock()
+	qi.drainHooks = append(qi.drainHooks, fn)
+}
+
+// LatencyHistogram returns the number of completed tasks per latency bucket, keyed by the bucket's upper bound.
+// Buckets are exponentially spaced, doubling from 1ms, and a task lands in the smallest bucket it fits in.
+// Only non-empty buckets are present. The returned map is a copy.
+func (qi *QueueImpl[T]) LatencyHistogram() map[time.Duration]int {
+	qi.completedMu.Lock()
+	defer qi.completedMu.Unlock()
+	histogram := make(map[time.Duration]int, len(qi.latencies))
+	for bound, count := range qi.latencies {
+		histogram[bound] = count
+	}
+	return histogram
+}
+
+// latencyBucket returns upper bound of the latency histogram bucket for latency.
+func latencyBucket(latency time.Duration) time.Duration {
+	bound := time.Millisecond
+	for bound < latency {
+		bound *= 2
+	}
+	return bound
+}
+
+// WaitN blocks until at least n tasks have completed since the call, or ctx is done.
+// Returns the context error if ctx is done before n tasks complete.
+func (qi *QueueImpl[T]) WaitN(ctx context.Context, n int) error {
+	qi.completedMu.Lock()
+	target := qi.completed + n
+	qi.completedMu.Unlock()
+	for {
+		qi.completedMu.Lock()
+		completed, signal := qi.completed, qi.completedSignal
+		qi.completedMu.Unlock()
+		if completed >= target {
+			return nil
+		}
+		select {
+		case <-signal:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// SetProcess atomically replaces the worker function. Tasks dequeued after the swap are processed
+// with fn, while tasks already being processed finish with the previous function.
+func (qi *QueueImpl[T]) SetProcess(fn func(T) error) {
+	qi.worker.Store(fn)
+}
+
+// Pause stops the queue from taking new tasks. If a task is being processed, Pause waits for it to finish,
+// and once Pause returns no task is started until Resume. As tasks are handed to the worker directly,
+// Push blocks until the queue is resumed. Pause must not be called from the worker function,
+// and does nothing on a drained queue.
+func (qi *QueueImpl[T]) Pause() {
+	qi.pauseMu.Lock()
+	defer qi.pauseMu.Unlock()
+	if qi.resumed != nil {
+		return
+	}
+	qi.stateMu.RLock()
+	done := qi.workerDone
+	qi.stateMu.RUnlock()
+	resumed := make(chan struct{})
+	select {
+	case qi.pauseRequests <- resumed:
+		qi.resumed = resumed
+	case <-done:
+	}
+}
+
+// Resume restarts processing of tasks after Pause. It does nothing if the queue is not paused.
+func (qi *QueueImpl[T]) Resume() {
+	qi.pauseMu.Lock()
+	defer qi.pauseMu.Unlock()
+	if qi.resumed != nil {
+		close(qi.resumed)
+		qi.resumed = nil
+	}
+}
+
+// Paused returns true if the queue is paused.
+func (qi *QueueImpl[T]) Paused() bool {
+	qi.pauseMu.Lock()
+	defer qi.pauseMu.Unlock()
+	return qi.resumed != nil
+}
+
+// Drain closes the queue, so further pushes fail with ErrorQueueClosed. A Drain called while a Push is blocked
+// on a paused queue waits until Resume.
 func (qi *QueueImpl[T]) Drain() {
 	qi.wg.Wait()
-	close(qi.items)
+	qi.stateMu.Lock()
+	defer qi.stateMu.Unlock()
+	if !qi.closed {
+		qi.closed = true
+		close(qi.items)
+	}
+}
+
+// Restart reopens a drained queue with a new worker, so the queue can be reused without reallocating.
+// Returns an error if the queue has not been drained, or if tasks pushed before Drain are still being processed
+// or the previous worker has not exited yet, for example because the queue is paused.
+func (qi *QueueImpl[T]) Restart() error {
+	qi.stateMu.Lock()
+	defer qi.stateMu.Unlock()
+	if !qi.closed {
+		return errors.New(ErrorQueueNotClosed)
+	}
+	qi.drainMu.Lock()
+	pending := qi.pending
+	qi.drainMu.Unlock()
+	if pending > 0 {
+		return errors.New(ErrorQueueDraining)
+	}
+	select {
+	case <-qi.workerDone:
+	default:
+		return errors.New(ErrorQueueDraining)
+	}
+	qi.items = make(chan task[T])
+	qi.closed = false
+	qi.workerDone = make(chan struct{})
+	go qi.workers(qi.items, qi.workerDone)
+	return nil
 }
 
 // Push add a new task to the queue. Calls callback once the worker has finished processing the task.
 func (qi *QueueImpl[T]) Push(value T, callback func(err error)) {
+	qi.stateMu.RLock()
+	defer qi.stateMu.RUnlock()
 	if !qi.closed {
+		qi.drainMu.Lock()
+		if qi.pending == 0 {
+			qi.idle = make(chan struct{})
+		}
+		qi.pending += 1
+		qi.drainMu.Unlock()
 		qi.items <- task[T]{value: value, errorCallback: callback}
 	} else {
 		callback(errors.New(ErrorQueueClosed))
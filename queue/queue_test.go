@@ -0,0 +1,413 @@
+package queue_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/skatiyar/goutils/queue"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetProcess(t *testing.T) {
+	t.Run("should process tasks pushed after swap with new function", func(nt *testing.T) {
+		rmu := sync.RWMutex{}
+		wg := sync.WaitGroup{}
+		results := make([]string, 0)
+		q := queue.NewQueue(func(val string) error {
+			defer wg.Done()
+			rmu.Lock()
+			defer rmu.Unlock()
+			results = append(results, "old "+val)
+			return nil
+		}, 1)
+		wg.Add(2)
+		q.Push("first", func(err error) {})
+		q.Push("second", func(err error) {})
+		wg.Wait()
+		q.SetProcess(func(val string) error {
+			defer wg.Done()
+			rmu.Lock()
+			defer rmu.Unlock()
+			results = append(results, "new "+val)
+			return nil
+		})
+		wg.Add(2)
+		q.Push("third", func(err error) {})
+		q.Push("fourth", func(err error) {})
+		wg.Wait()
+		q.Drain()
+		assert.Equal(nt, results, []string{"old first", "old second", "new third", "new fourth"})
+	})
+}
+
+func TestWaitN(t *testing.T) {
+	t.Run("should return once n tasks have completed", func(nt *testing.T) {
+		var completed int32
+		q := queue.NewQueue(func(val int) error {
+			time.Sleep(10 * time.Millisecond)
+			atomic.AddInt32(&completed, 1)
+			return nil
+		}, 1)
+		pushed := make(chan struct{})
+		go func() {
+			defer close(pushed)
+			time.Sleep(20 * time.Millisecond)
+			for idx := 0; idx < 10; idx++ {
+				q.Push(idx, func(err error) {})
+			}
+		}()
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		assert.NoError(nt, q.WaitN(ctx, 5))
+		assert.GreaterOrEqual(nt, atomic.LoadInt32(&completed), int32(5))
+		<-pushed
+		q.Drain()
+	})
+	t.Run("should return context error when tasks do not complete in time", func(nt *testing.T) {
+		q := queue.NewQueue(func(val int) error {
+			return nil
+		}, 1)
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		assert.ErrorIs(nt, q.WaitN(ctx, 1), context.DeadlineExceeded)
+		q.Drain()
+	})
+}
+
+func TestThroughput(t *testing.T) {
+	t.Run("should return zero before tasks complete", func(nt *testing.T) {
+		q := queue.NewQueue(func(val int) error {
+			return nil
+		}, 1)
+		assert.Equal(nt, q.Throughput(), float64(0))
+		q.Drain()
+	})
+	t.Run("should be positive while tasks complete", func(nt *testing.T) {
+		q := queue.NewQueue(func(val int) error {
+			time.Sleep(10 * time.Millisecond)
+			return nil
+		}, 1)
+		for idx := 0; idx < 30; idx++ {
+			q.Push(idx, func(err error) {})
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		assert.NoError(nt, q.WaitN(ctx, 1))
+		assert.Greater(nt, q.Throughput(), float64(0))
+		q.Drain()
+	})
+	t.Run("should decay once queue is idle", func(nt *testing.T) {
+		q := queue.NewQueue(func(val int) error {
+			time.Sleep(10 * time.Millisecond)
+			return nil
+		}, 1)
+		for idx := 0; idx < 5; idx++ {
+			q.Push(idx, func(err error) {})
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		assert.NoError(nt, q.Wait(ctx))
+		busy := q.Throughput()
+		assert.Greater(nt, busy, float64(0))
+		time.Sleep(200 * time.Millisecond)
+		assert.Less(nt, q.Throughput(), busy)
+		q.Drain()
+	})
+}
+
+func TestLatencyHistogram(t *testing.T) {
+	t.Run("should return empty histogram before tasks complete", func(nt *testing.T) {
+		q := queue.NewQueue(func(val int) error {
+			return nil
+		}, 1)
+		assert.Empty(nt, q.LatencyHistogram())
+		q.Drain()
+	})
+	t.Run("should count tasks in exponential latency buckets", func(nt *testing.T) {
+		release := make(chan struct{})
+		q := queue.NewQueue(func(val int) error {
+			if val < 0 {
+				<-release
+				return nil
+			}
+			time.Sleep(time.Duration(val) * time.Millisecond)
+			return nil
+		}, 1)
+		for _, val := range []int{0, 50, 0, 50} {
+			q.Push(val, func(err error) {})
+		}
+		// blocks until previous tasks are completed, as the queue processes one task at a time
+		q.Push(-1, func(err error) {})
+		fast, slow := 0, 0
+		for bound, count := range q.LatencyHistogram() {
+			// bounds double from 1ms
+			assert.Equal(nt, int64(bound/time.Millisecond)&int64(bound/time.Millisecond-1), int64(0))
+			if bound <= 32*time.Millisecond {
+				fast += count
+			} else {
+				slow += count
+			}
+		}
+		assert.Equal(nt, fast, 2)
+		assert.Equal(nt, slow, 2)
+		close(release)
+		q.Drain()
+	})
+}
+
+func TestPauseResume(t *testing.T) {
+	t.Run("should not process tasks while paused", func(nt *testing.T) {
+		var processed int32
+		q := queue.NewQueue(func(val int) error {
+			atomic.AddInt32(&processed, 1)
+			return nil
+		}, 1)
+		q.Push(1, func(err error) {})
+		q.Pause()
+		assert.True(nt, q.Paused())
+		assert.Equal(nt, atomic.LoadInt32(&processed), int32(1))
+		pushed := make(chan struct{})
+		go func() {
+			q.Push(2, func(err error) {})
+			close(pushed)
+		}()
+		time.Sleep(50 * time.Millisecond)
+		assert.Equal(nt, atomic.LoadInt32(&processed), int32(1))
+		q.Resume()
+		assert.False(nt, q.Paused())
+		<-pushed
+		// blocks until the previous task is completed, as the queue processes one task at a time
+		q.Push(3, func(err error) {})
+		assert.GreaterOrEqual(nt, atomic.LoadInt32(&processed), int32(2))
+		q.Drain()
+	})
+	t.Run("should not start tasks once pause returns", func(nt *testing.T) {
+		var processed int32
+		q := queue.NewQueue(func(val int) error {
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&processed, 1)
+			return nil
+		}, 1)
+		stop := make(chan struct{})
+		stopped := make(chan struct{})
+		go func() {
+			defer close(stopped)
+			for idx := 0; ; idx++ {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				q.Push(idx, func(err error) {})
+			}
+		}()
+		time.Sleep(20 * time.Millisecond)
+		q.Pause()
+		count := atomic.LoadInt32(&processed)
+		time.Sleep(50 * time.Millisecond)
+		assert.Equal(nt, atomic.LoadInt32(&processed), count)
+		close(stop)
+		q.Resume()
+		<-stopped
+		q.Drain()
+	})
+	t.Run("should make drain wait for blocked push until resume", func(nt *testing.T) {
+		q := queue.NewQueue(func(val int) error {
+			return nil
+		}, 1)
+		q.Pause()
+		go q.Push(1, func(err error) {})
+		time.Sleep(10 * time.Millisecond)
+		drained := make(chan struct{})
+		go func() {
+			q.Drain()
+			close(drained)
+		}()
+		select {
+		case <-drained:
+			nt.Fatal("drain returned while push was blocked")
+		case <-time.After(50 * time.Millisecond):
+		}
+		q.Resume()
+		select {
+		case <-drained:
+		case <-time.After(time.Second):
+			nt.Fatal("drain did not return after resume")
+		}
+	})
+	t.Run("should ignore resume when not paused", func(nt *testing.T) {
+		q := queue.NewQueue(func(val int) error {
+			return nil
+		}, 1)
+		q.Resume()
+		assert.False(nt, q.Paused())
+		q.Drain()
+	})
+}
+
+func TestOnDrain(t *testing.T) {
+	t.Run("should call callback once all pushed tasks are processed", func(nt *testing.T) {
+		var processed, drains int32
+		drained := make(chan int32, 2)
+		q := queue.NewQueue(func(val int) error {
+			time.Sleep(20 * time.Millisecond)
+			atomic.AddInt32(&processed, 1)
+			return nil
+		}, 1)
+		q.OnDrain(func() {
+			atomic.AddInt32(&drains, 1)
+			drained <- atomic.LoadInt32(&processed)
+		})
+		for idx := 0; idx < 3; idx++ {
+			q.Push(idx, func(err error) {})
+		}
+		select {
+		case count := <-drained:
+			assert.Equal(nt, count, int32(3))
+		case <-time.After(time.Second):
+			nt.Fatal("drain callback was not called")
+		}
+		q.Push(3, func(err error) {})
+		select {
+		case count := <-drained:
+			assert.Equal(nt, count, int32(4))
+		case <-time.After(time.Second):
+			nt.Fatal("drain callback was not called")
+		}
+		assert.Equal(nt, atomic.LoadInt32(&drains), int32(2))
+		q.Drain()
+	})
+}
+
+func TestRestart(t *testing.T) {
+	t.Run("should reject pushes once drained", func(nt *testing.T) {
+		q := queue.NewQueue(func(val int) error {
+			return nil
+		}, 1)
+		q.Drain()
+		var pushErr error
+		q.Push(1, func(err error) { pushErr = err })
+		assert.EqualError(nt, pushErr, queue.ErrorQueueClosed)
+	})
+	t.Run("should process tasks after restart", func(nt *testing.T) {
+		var processed int32
+		q := queue.NewQueue(func(val int) error {
+			atomic.AddInt32(&processed, 1)
+			return nil
+		}, 1)
+		q.Push(1, func(err error) {})
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		assert.NoError(nt, q.Wait(ctx))
+		q.Drain()
+		// the worker exits asynchronously once drained, so restart may briefly report the queue as draining
+		assert.Eventually(nt, func() bool {
+			return q.Restart() == nil
+		}, time.Second, time.Millisecond)
+		q.Push(2, func(err error) {})
+		assert.NoError(nt, q.Wait(ctx))
+		assert.Equal(nt, atomic.LoadInt32(&processed), int32(2))
+		q.Drain()
+	})
+	t.Run("should return error if queue is not drained", func(nt *testing.T) {
+		q := queue.NewQueue(func(val int) error {
+			return nil
+		}, 1)
+		assert.EqualError(nt, q.Restart(), queue.ErrorQueueNotClosed)
+		q.Drain()
+	})
+	t.Run("should return error while tasks are still processing", func(nt *testing.T) {
+		release := make(chan struct{})
+		q := queue.NewQueue(func(val int) error {
+			<-release
+			return nil
+		}, 1)
+		q.Push(1, func(err error) {})
+		q.Drain()
+		assert.EqualError(nt, q.Restart(), queue.ErrorQueueDraining)
+		close(release)
+	})
+}
+
+func TestWait(t *testing.T) {
+	t.Run("should return immediately for idle queue", func(nt *testing.T) {
+		q := queue.NewQueue(func(val int) error {
+			return nil
+		}, 1)
+		assert.NoError(nt, q.Wait(context.Background()))
+		q.Drain()
+	})
+	t.Run("should return once pushed tasks are processed and allow further pushes", func(nt *testing.T) {
+		var processed int32
+		q := queue.NewQueue(func(val int) error {
+			time.Sleep(20 * time.Millisecond)
+			atomic.AddInt32(&processed, 1)
+			return nil
+		}, 1)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		for idx := 0; idx < 3; idx++ {
+			q.Push(idx, func(err error) {})
+		}
+		assert.NoError(nt, q.Wait(ctx))
+		assert.Equal(nt, atomic.LoadInt32(&processed), int32(3))
+		q.Push(3, func(err error) {})
+		assert.NoError(nt, q.Wait(ctx))
+		assert.Equal(nt, atomic.LoadInt32(&processed), int32(4))
+		q.Drain()
+	})
+	t.Run("should return context error when queue does not empty in time", func(nt *testing.T) {
+		release := make(chan struct{})
+		q := queue.NewQueue(func(val int) error {
+			<-release
+			return nil
+		}, 1)
+		q.Push(1, func(err error) {})
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		assert.ErrorIs(nt, q.Wait(ctx), context.DeadlineExceeded)
+		close(release)
+		q.Drain()
+	})
+}
+
+func TestMetrics(t *testing.T) {
+	t.Run("should return zero metrics before tasks complete", func(nt *testing.T) {
+		q := queue.NewQueue(func(val int) error {
+			return nil
+		}, 1)
+		assert.Equal(nt, q.Metrics(), queue.Metrics{})
+		q.Drain()
+	})
+	t.Run("should count succeeded, failed and panicked tasks", func(nt *testing.T) {
+		q := queue.NewQueue(func(val int) error {
+			time.Sleep(10 * time.Millisecond)
+			switch val {
+			case 1:
+				return errors.New("some error")
+			case 2:
+				panic("boom")
+			}
+			return nil
+		}, 1)
+		errs := make(chan error, 2)
+		for _, val := range []int{0, 1, 2, 3} {
+			q.Push(val, func(err error) { errs <- err })
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		assert.NoError(nt, q.Wait(ctx))
+		metrics := q.Metrics()
+		assert.Equal(nt, metrics.Processed, int64(4))
+		assert.Equal(nt, metrics.Succeeded, int64(2))
+		assert.Equal(nt, metrics.Failed, int64(2))
+		assert.GreaterOrEqual(nt, metrics.AverageDuration, 10*time.Millisecond)
+		assert.EqualError(nt, <-errs, "some error")
+		assert.EqualError(nt, <-errs, "panic in worker: boom")
+		q.Drain()
+	})
+}
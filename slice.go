@@ -1,5 +1,13 @@
 package goutils
 
+import (
+	"container/heap"
+	"math/rand"
+	"sort"
+
+	"github.com/skatiyar/goutils/constraints"
+)
+
 // ConcatSlice applies iteratee to each item in slice, concatenating the results and returns the concatenated list.
 // The results array will be ordered with respect to slice provided.
 // If iterator returns an error, function returns immediately with an error and result as nil.
@@ -44,6 +52,26 @@ func EachSlice[A any](collection []A, fn func(value A, idx int) error) error {
 	return nil
 }
 
+// EachBatchSlice applies the function iteratee to consecutive batches of up to size items from slice,
+// the last batch holding the remainder. Batches share the backing array of slice.
+// Function returns with ErrInvalidBatchSize if size is not positive.
+// If the iterator returns an error, function returns immediately with an error.
+func EachBatchSlice[A any](collection []A, size int, fn func(batch []A) error) error {
+	if size <= 0 {
+		return ErrInvalidBatchSize
+	}
+	for start := 0; start < len(collection); start += size {
+		end := start + size
+		if end > len(collection) {
+			end = len(collection)
+		}
+		if err := fn(collection[start:end:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Slice produces a new slice by mapping each value in slice through the iteratee function.
 // The iteratee is called with value from slice, returns new value.
 // If the iterator returns an error, function returns immediately with an error.
@@ -72,6 +100,15 @@ func ReduceSlice[A any, X any](collection []A, fn func(accumulator X, value A, i
 	return initial, nil
 }
 
+// FoldSlice reduces slice into a single value using an iteratee which never fails.
+// It is a simpler form of ReduceSlice for pure reductions.
+func FoldSlice[A any, X any](collection []A, fn func(accumulator X, value A) X, initial X) X {
+	for _, value := range collection {
+		initial = fn(initial, value)
+	}
+	return initial
+}
+
 // ReduceRightSlice reduces slice from right into a single value using an iteratee to return each successive step.
 // If the iterator returns an error, function returns immediately with an error.
 func ReduceRightSlice[A any, X any](collection []A, fn func(accumulator X, value A, idx int) (X, error), initial X) (X, error) {
@@ -98,6 +135,20 @@ func EverySlice[A any](collection []A, fn func(value A, idx int) (bool, error))
 	return true, nil
 }
 
+// CountMatchingSlice returns the number of elements in slice which pass truth test.
+// If the iterator returns an error, function returns immediately with an error.
+func CountMatchingSlice[A any](collection []A, fn func(value A, idx int) (bool, error)) (int, error) {
+	count := 0
+	for idx, value := range collection {
+		if test, testErr := fn(value, idx); testErr != nil {
+			return 0, testErr
+		} else if test {
+			count += 1
+		}
+	}
+	return count, nil
+}
+
 // FilterSlice returns a new slice of all the values in slice which pass truth test.
 // If the iterator returns an error, function returns immediately with an error.
 func FilterSlice[A any](collection []A, fn func(value A, idx int) (bool, error)) ([]A, error) {
@@ -129,6 +180,44 @@ func GroupBySlice[A any, X comparable, Y any](collection []A, fn func(value A, i
 	return result, nil
 }
 
+// GroupReduceSlice groups values of slice by the key returned by keyFn and reduces each group in the same pass,
+// without storing group members. Each group starts from the value returned by initial.
+// If the iterator or reducer returns an error, function returns immediately with an error.
+func GroupReduceSlice[A any, K comparable, R any](collection []A, keyFn func(value A, idx int) (K, error), reduce func(accumulator R, value A) (R, error), initial func() R) (map[K]R, error) {
+	result := make(map[K]R)
+	for idx, value := range collection {
+		if group, groupErr := keyFn(value, idx); groupErr != nil {
+			return nil, groupErr
+		} else {
+			acc, ok := result[group]
+			if !ok {
+				acc = initial()
+			}
+			if acc, accErr := reduce(acc, value); accErr != nil {
+				return nil, accErr
+			} else {
+				result[group] = acc
+			}
+		}
+	}
+	return result, nil
+}
+
+// SplitSlice returns a new map, where each value is a slice of items from slice assigned to the bucket index returned by classify.
+// It generalizes a boolean partition to any number of buckets. Items within a bucket keep the order of slice.
+// If the iterator returns an error, function returns immediately with an error.
+func SplitSlice[A any](collection []A, classify func(value A, idx int) (int, error)) (map[int][]A, error) {
+	result := make(map[int][]A)
+	for idx, value := range collection {
+		if bucket, bucketErr := classify(value, idx); bucketErr != nil {
+			return nil, bucketErr
+		} else {
+			result[bucket] = append(result[bucket], value)
+		}
+	}
+	return result, nil
+}
+
 // RejectSlice is the opposite of FilterSlice. Removes values that pass truth test.
 // If the iterator returns an error, function returns immediately with an error.
 func RejectSlice[A any](collection []A, fn func(value A, idx int) (bool, error)) ([]A, error) {
@@ -155,3 +244,195 @@ func SomeSlice[A any](collection []A, fn func(value A, idx int) (bool, error)) (
 	}
 	return false, nil
 }
+
+// RotateSlice returns a new slice with the values of slice rotated left by n positions.
+// Negative n rotates right, and n larger than length of slice wraps around.
+func RotateSlice[A any](collection []A, n int) []A {
+	result := make([]A, 0, len(collection))
+	if len(collection) == 0 {
+		return result
+	}
+	shift := n % len(collection)
+	if shift < 0 {
+		shift += len(collection)
+	}
+	result = append(result, collection[shift:]...)
+	return append(result, collection[:shift]...)
+}
+
+// FlattenUniq returns a new slice of unique values from all inner slices of collection,
+// in order of their first occurrence.
+func FlattenUniq[A comparable](collection [][]A) []A {
+	result := make([]A, 0)
+	seen := make(map[A]struct{})
+	for _, inner := range collection {
+		for _, value := range inner {
+			if _, ok := seen[value]; !ok {
+				seen[value] = struct{}{}
+				result = append(result, value)
+			}
+		}
+	}
+	return result
+}
+
+// InsertAtSlice returns a new slice with values inserted into slice at index idx.
+// idx may be equal to length of slice to append values. For any other index outside slice,
+// function returns with ErrIndexOutOfRange.
+func InsertAtSlice[A any](collection []A, idx int, values ...A) ([]A, error) {
+	if idx < 0 || idx > len(collection) {
+		return nil, ErrIndexOutOfRange
+	}
+	result := make([]A, 0, len(collection)+len(values))
+	result = append(result, collection[:idx]...)
+	result = append(result, values...)
+	return append(result, collection[idx:]...), nil
+}
+
+// RemoveAtSlice returns a new slice with the value at index idx removed from slice.
+// If idx is outside slice, function returns with ErrIndexOutOfRange.
+func RemoveAtSlice[A any](collection []A, idx int) ([]A, error) {
+	if idx < 0 || idx >= len(collection) {
+		return nil, ErrIndexOutOfRange
+	}
+	result := make([]A, 0, len(collection)-1)
+	result = append(result, collection[:idx]...)
+	return append(result, collection[idx+1:]...), nil
+}
+
+// WeightedSampleSlice returns n values selected from slice without replacement, where the chance of a value
+// being selected is proportional to its weight among the values not yet selected. Values are returned in order of selection.
+// Values with zero weight are only selected, uniformly, once all values with positive weight are selected.
+// r is used as the source of randomness, so a seeded r gives reproducible samples.
+// Function returns with ErrInvalidSampleSize if n is negative or larger than length of slice,
+// and with ErrNegativeWeight if any weight is negative.
+func WeightedSampleSlice[A any](collection []A, weightFn func(value A) float64, n int, r *rand.Rand) ([]A, error) {
+	if n < 0 || n > len(collection) {
+		return nil, ErrInvalidSampleSize
+	}
+	weights := make([]float64, len(collection))
+	remaining := make([]int, len(collection))
+	total := float64(0)
+	for idx, value := range collection {
+		weight := weightFn(value)
+		if weight < 0 {
+			return nil, ErrNegativeWeight
+		}
+		weights[idx], remaining[idx] = weight, idx
+		total += weight
+	}
+	result := make([]A, 0, n)
+	for len(result) < n {
+		pick := -1
+		if total > 0 {
+			target := r.Float64() * total
+			for pidx, idx := range remaining {
+				if weights[idx] == 0 {
+					continue
+				}
+				// Last value with positive weight is kept as pick, in case rounding leaves target non-negative.
+				pick = pidx
+				if target -= weights[idx]; target < 0 {
+					break
+				}
+			}
+		}
+		if pick < 0 {
+			pick = r.Intn(len(remaining))
+		}
+		idx := remaining[pick]
+		result = append(result, collection[idx])
+		total -= weights[idx]
+		remaining = append(remaining[:pick], remaining[pick+1:]...)
+	}
+	return result, nil
+}
+
+// sliceCursor points at the next value of one of the slices merged by MergeSortedSlices.
+type sliceCursor struct {
+	slice int
+	idx   int
+}
+
+// cursorHeap is a min-heap of slice cursors ordered by the values they point at.
+type cursorHeap[A constraints.Ordered] struct {
+	slices  [][]A
+	cursors []sliceCursor
+}
+
+func (ch *cursorHeap[A]) Len() int { return len(ch.cursors) }
+
+func (ch *cursorHeap[A]) Less(i, j int) bool {
+	a, b := ch.cursors[i], ch.cursors[j]
+	if va, vb := ch.slices[a.slice][a.idx], ch.slices[b.slice][b.idx]; va != vb {
+		return va < vb
+	}
+	return a.slice < b.slice
+}
+
+func (ch *cursorHeap[A]) Swap(i, j int) { ch.cursors[i], ch.cursors[j] = ch.cursors[j], ch.cursors[i] }
+
+func (ch *cursorHeap[A]) Push(x any) { ch.cursors = append(ch.cursors, x.(sliceCursor)) }
+
+func (ch *cursorHeap[A]) Pop() any {
+	last := ch.cursors[len(ch.cursors)-1]
+	ch.cursors = ch.cursors[:len(ch.cursors)-1]
+	return last
+}
+
+// MergeSortedSlices merges slices, each already sorted in ascending order, into a new sorted slice
+// using a heap-based k-way merge. Equal values keep the order of the slices they come from.
+func MergeSortedSlices[A constraints.Ordered](slices ...[]A) []A {
+	total := 0
+	ch := &cursorHeap[A]{slices: slices, cursors: make([]sliceCursor, 0, len(slices))}
+	for sidx, slice := range slices {
+		total += len(slice)
+		if len(slice) > 0 {
+			ch.cursors = append(ch.cursors, sliceCursor{slice: sidx})
+		}
+	}
+	heap.Init(ch)
+	result := make([]A, 0, total)
+	for ch.Len() > 0 {
+		cursor := ch.cursors[0]
+		result = append(result, slices[cursor.slice][cursor.idx])
+		if cursor.idx+1 < len(slices[cursor.slice]) {
+			ch.cursors[0].idx += 1
+			heap.Fix(ch, 0)
+		} else {
+			heap.Pop(ch)
+		}
+	}
+	return result
+}
+
+// DedupeConsecutiveSlice returns a new slice with runs of equal adjacent values of slice collapsed into one,
+// like Unix uniq. Unlike a full dedupe, equal values which are not adjacent are kept.
+func DedupeConsecutiveSlice[A comparable](collection []A) []A {
+	return DedupeConsecutiveBySlice(collection, func(value A) A { return value })
+}
+
+// DedupeConsecutiveBySlice is same as DedupeConsecutiveSlice, but compares adjacent values by the key returned by keyFn.
+// The first value of each run is kept.
+func DedupeConsecutiveBySlice[A any, K comparable](collection []A, keyFn func(value A) K) []A {
+	result := make([]A, 0, len(collection))
+	var lastKey K
+	for idx, value := range collection {
+		key := keyFn(value)
+		if idx == 0 || key != lastKey {
+			result = append(result, value)
+			lastKey = key
+		}
+	}
+	return result
+}
+
+// SearchSortedSlice binary searches slice, sorted in ascending order of the key returned by keyFn, for target.
+// It returns the index of the first value whose key equals target and true, or if there is no such value,
+// the index at which target would be inserted to keep slice sorted and false.
+func SearchSortedSlice[A any, K constraints.Ordered](collection []A, target K, keyFn func(value A) K) (int, bool) {
+	idx := sort.Search(len(collection), func(i int) bool {
+		return keyFn(collection[i]) >= target
+	})
+	return idx, idx < len(collection) && keyFn(collection[idx]) == target
+}
@@ -2,6 +2,8 @@ package goutils_test
 
 import (
 	"errors"
+	"math/rand"
+	"sort"
 	"strings"
 	"testing"
 
@@ -80,6 +82,45 @@ func TestEachSlice(t *testing.T) {
 	})
 }
 
+func TestEachBatchSlice(t *testing.T) {
+	t.Run("should call iterator with exact batches", func(nt *testing.T) {
+		batches := make([][]int, 0)
+		err := goutils.EachBatchSlice([]int{1, 2, 3, 4, 5, 6}, 3, func(batch []int) error {
+			batches = append(batches, batch)
+			return nil
+		})
+		assert.NoError(nt, err)
+		assert.Equal(nt, batches, [][]int{{1, 2, 3}, {4, 5, 6}})
+	})
+	t.Run("should call iterator with remainder batch", func(nt *testing.T) {
+		batches := make([][]int, 0)
+		err := goutils.EachBatchSlice([]int{1, 2, 3, 4, 5}, 2, func(batch []int) error {
+			batches = append(batches, batch)
+			return nil
+		})
+		assert.NoError(nt, err)
+		assert.Equal(nt, batches, [][]int{{1, 2}, {3, 4}, {5}})
+	})
+	t.Run("should return error when iterator returns error", func(nt *testing.T) {
+		calls := 0
+		err := goutils.EachBatchSlice([]int{1, 2, 3, 4, 5}, 2, func(batch []int) error {
+			calls += 1
+			if batch[0] == 3 {
+				return errors.New("some error")
+			}
+			return nil
+		})
+		assert.EqualError(nt, err, "some error")
+		assert.Equal(nt, calls, 2)
+	})
+	t.Run("should return error for invalid size", func(nt *testing.T) {
+		err := goutils.EachBatchSlice([]int{1, 2}, 0, func(batch []int) error {
+			return nil
+		})
+		assert.ErrorIs(nt, err, goutils.ErrInvalidBatchSize)
+	})
+}
+
 func TestSlice(t *testing.T) {
 	t.Run("should return correct values when iterator returns no error", func(nt *testing.T) {
 		collection := []string{"the brown", "fox", "jumps over the", "brown fence"}
@@ -124,6 +165,27 @@ func TestReduceSlice(t *testing.T) {
 	})
 }
 
+func TestFoldSlice(t *testing.T) {
+	t.Run("should return sum of values", func(nt *testing.T) {
+		collection := []int{2, 7, 8, 9, 1, 3}
+		assert.Equal(nt, goutils.FoldSlice(collection, func(acc int, val int) int {
+			return acc + val
+		}, 0), 30)
+	})
+	t.Run("should return concatenated values", func(nt *testing.T) {
+		collection := []string{"the brown", "fox", "jumps over the", "brown fence"}
+		collectionResult := "the brown fox jumps over the brown fence"
+		assert.Equal(nt, goutils.FoldSlice(collection, func(acc string, val string) string {
+			return strings.TrimLeft(acc+" "+val, " ")
+		}, ""), collectionResult)
+	})
+	t.Run("should return initial value for empty slice", func(nt *testing.T) {
+		assert.Equal(nt, goutils.FoldSlice([]int{}, func(acc int, val int) int {
+			return acc + val
+		}, 10), 10)
+	})
+}
+
 func TestReduceRightSlice(t *testing.T) {
 	t.Run("should return correct values when iterator returns no error", func(nt *testing.T) {
 		collection := []string{"the brown", "fox", "jumps over the", "brown fence"}
@@ -175,6 +237,25 @@ func TestEverySlice(t *testing.T) {
 	})
 }
 
+func TestCountMatchingSlice(t *testing.T) {
+	t.Run("should return count of values passing test", func(nt *testing.T) {
+		collection := []string{"the brown", "fly", "jumps over the", "brown fence", "2024"}
+		count, countErr := goutils.CountMatchingSlice(collection, func(val string, idx int) (bool, error) {
+			return strings.ContainsAny(val, "aeiou"), nil
+		})
+		assert.NoError(nt, countErr)
+		assert.Equal(nt, count, 3)
+	})
+	t.Run("should return correct values when iterator returns error", func(nt *testing.T) {
+		collection := []string{"the brown", "fly", "jumps over the", "brown fence"}
+		count, countErr := goutils.CountMatchingSlice(collection, func(val string, idx int) (bool, error) {
+			return strings.ContainsAny(val, "aeiou"), errors.New("an error")
+		})
+		assert.Error(nt, countErr)
+		assert.Equal(nt, count, 0)
+	})
+}
+
 func TestFilterSlice(t *testing.T) {
 	t.Run("should return correct values when iterator returns no error", func(nt *testing.T) {
 		collection := []string{"the brown", "fox", "jumps over the", "brown fence"}
@@ -214,6 +295,74 @@ func TestGroupBySlice(t *testing.T) {
 	})
 }
 
+func TestGroupReduceSlice(t *testing.T) {
+	collection := []int{1, 2, 3, 4, 5, 6, 7}
+	keyFn := func(value int, idx int) (string, error) {
+		if value%2 == 0 {
+			return "even", nil
+		}
+		return "odd", nil
+	}
+	t.Run("should compute per group aggregates", func(nt *testing.T) {
+		result, resultErr := goutils.GroupReduceSlice(collection, keyFn, func(acc int, value int) (int, error) {
+			return acc + value, nil
+		}, func() int { return 0 })
+		assert.NoError(nt, resultErr)
+		assert.Equal(nt, result, map[string]int{"even": 12, "odd": 16})
+	})
+	t.Run("should match group by then reduce", func(nt *testing.T) {
+		grouped, groupedErr := goutils.GroupBySlice(collection, func(value int, idx int) (string, int, error) {
+			key, err := keyFn(value, idx)
+			return key, value, err
+		})
+		assert.NoError(nt, groupedErr)
+		expected := make(map[string]int)
+		for key, members := range grouped {
+			expected[key] = goutils.FoldSlice(members, func(acc int, value int) int { return acc * value }, 1)
+		}
+		result, resultErr := goutils.GroupReduceSlice(collection, keyFn, func(acc int, value int) (int, error) {
+			return acc * value, nil
+		}, func() int { return 1 })
+		assert.NoError(nt, resultErr)
+		assert.Equal(nt, result, expected)
+	})
+	t.Run("should return error", func(nt *testing.T) {
+		result, resultErr := goutils.GroupReduceSlice(collection, keyFn, func(acc int, value int) (int, error) {
+			if value == 4 {
+				return acc, errors.New("some error")
+			}
+			return acc + value, nil
+		}, func() int { return 0 })
+		assert.EqualError(nt, resultErr, "some error")
+		assert.Nil(nt, result)
+	})
+}
+
+func TestSplitSlice(t *testing.T) {
+	t.Run("should return values classified into buckets preserving order", func(nt *testing.T) {
+		collection := []int{-3, 0, 5, -1, 2, 0, 7}
+		split, splitErr := goutils.SplitSlice(collection, func(val int, idx int) (int, error) {
+			switch {
+			case val < 0:
+				return -1, nil
+			case val > 0:
+				return 1, nil
+			default:
+				return 0, nil
+			}
+		})
+		assert.NoError(nt, splitErr)
+		assert.Equal(nt, split, map[int][]int{-1: {-3, -1}, 0: {0, 0}, 1: {5, 2, 7}})
+	})
+	t.Run("should return correct values when iterator returns error", func(nt *testing.T) {
+		split, splitErr := goutils.SplitSlice([]int{-3, 0, 5}, func(val int, idx int) (int, error) {
+			return 0, errors.New("an error")
+		})
+		assert.Error(nt, splitErr)
+		assert.Nil(nt, split)
+	})
+}
+
 func TestRejectSlice(t *testing.T) {
 	t.Run("should return correct values when iterator returns no error", func(nt *testing.T) {
 		collection := []string{"the brown", "fox", "jumps over the", "brown fence"}
@@ -259,3 +408,238 @@ func TestSomeSlice(t *testing.T) {
 		assert.False(nt, mapped)
 	})
 }
+
+func TestRotateSlice(t *testing.T) {
+	t.Run("should rotate left for positive n", func(nt *testing.T) {
+		assert.Equal(nt, goutils.RotateSlice([]int{1, 2, 3, 4, 5}, 2), []int{3, 4, 5, 1, 2})
+	})
+	t.Run("should rotate right for negative n", func(nt *testing.T) {
+		assert.Equal(nt, goutils.RotateSlice([]int{1, 2, 3, 4, 5}, -2), []int{4, 5, 1, 2, 3})
+	})
+	t.Run("should return copy for zero n", func(nt *testing.T) {
+		collection := []int{1, 2, 3, 4, 5}
+		rotated := goutils.RotateSlice(collection, 0)
+		assert.Equal(nt, rotated, collection)
+		rotated[0] = 10
+		assert.Equal(nt, collection[0], 1)
+	})
+	t.Run("should wrap around for n larger than length", func(nt *testing.T) {
+		assert.Equal(nt, goutils.RotateSlice([]int{1, 2, 3, 4, 5}, 12), []int{3, 4, 5, 1, 2})
+		assert.Equal(nt, goutils.RotateSlice([]int{1, 2, 3, 4, 5}, -12), []int{4, 5, 1, 2, 3})
+	})
+	t.Run("should return empty slice for empty slice", func(nt *testing.T) {
+		assert.Equal(nt, goutils.RotateSlice([]int{}, 3), []int{})
+	})
+}
+
+func TestFlattenUniq(t *testing.T) {
+	t.Run("should return unique values in first occurrence order", func(nt *testing.T) {
+		collection := [][]string{{"the", "brown", "fox"}, {"fox", "jumps", "over"}, {"the", "brown", "fence"}}
+		assert.Equal(nt, goutils.FlattenUniq(collection), []string{"the", "brown", "fox", "jumps", "over", "fence"})
+	})
+	t.Run("should return empty slice for empty collection", func(nt *testing.T) {
+		assert.Equal(nt, goutils.FlattenUniq([][]int{{}, {}}), []int{})
+	})
+}
+
+func TestInsertAtSlice(t *testing.T) {
+	t.Run("should insert values at boundary and middle indices", func(nt *testing.T) {
+		collection := []int{1, 2, 3}
+		inserted, insertedErr := goutils.InsertAtSlice(collection, 0, 8, 9)
+		assert.NoError(nt, insertedErr)
+		assert.Equal(nt, inserted, []int{8, 9, 1, 2, 3})
+		inserted, insertedErr = goutils.InsertAtSlice(collection, 1, 8)
+		assert.NoError(nt, insertedErr)
+		assert.Equal(nt, inserted, []int{1, 8, 2, 3})
+		inserted, insertedErr = goutils.InsertAtSlice(collection, 3, 8)
+		assert.NoError(nt, insertedErr)
+		assert.Equal(nt, inserted, []int{1, 2, 3, 8})
+		assert.Equal(nt, collection, []int{1, 2, 3})
+	})
+	t.Run("should return error for out of range index", func(nt *testing.T) {
+		inserted, insertedErr := goutils.InsertAtSlice([]int{1, 2, 3}, 4, 8)
+		assert.ErrorIs(nt, insertedErr, goutils.ErrIndexOutOfRange)
+		assert.Nil(nt, inserted)
+		inserted, insertedErr = goutils.InsertAtSlice([]int{1, 2, 3}, -1, 8)
+		assert.ErrorIs(nt, insertedErr, goutils.ErrIndexOutOfRange)
+		assert.Nil(nt, inserted)
+	})
+}
+
+func TestRemoveAtSlice(t *testing.T) {
+	t.Run("should remove value at boundary and middle indices", func(nt *testing.T) {
+		collection := []int{1, 2, 3}
+		removed, removedErr := goutils.RemoveAtSlice(collection, 0)
+		assert.NoError(nt, removedErr)
+		assert.Equal(nt, removed, []int{2, 3})
+		removed, removedErr = goutils.RemoveAtSlice(collection, 1)
+		assert.NoError(nt, removedErr)
+		assert.Equal(nt, removed, []int{1, 3})
+		removed, removedErr = goutils.RemoveAtSlice(collection, 2)
+		assert.NoError(nt, removedErr)
+		assert.Equal(nt, removed, []int{1, 2})
+		assert.Equal(nt, collection, []int{1, 2, 3})
+	})
+	t.Run("should return error for out of range index", func(nt *testing.T) {
+		removed, removedErr := goutils.RemoveAtSlice([]int{1, 2, 3}, 3)
+		assert.ErrorIs(nt, removedErr, goutils.ErrIndexOutOfRange)
+		assert.Nil(nt, removed)
+		removed, removedErr = goutils.RemoveAtSlice([]int{}, 0)
+		assert.ErrorIs(nt, removedErr, goutils.ErrIndexOutOfRange)
+		assert.Nil(nt, removed)
+	})
+}
+
+func TestWeightedSampleSlice(t *testing.T) {
+	t.Run("should return reproducible samples for seeded rand", func(nt *testing.T) {
+		collection := []string{"the", "brown", "fox", "jumps", "over", "fence"}
+		weightFn := func(val string) float64 {
+			return float64(len(val))
+		}
+		first, firstErr := goutils.WeightedSampleSlice(collection, weightFn, 3, rand.New(rand.NewSource(42)))
+		assert.NoError(nt, firstErr)
+		second, secondErr := goutils.WeightedSampleSlice(collection, weightFn, 3, rand.New(rand.NewSource(42)))
+		assert.NoError(nt, secondErr)
+		assert.Len(nt, first, 3)
+		assert.Equal(nt, first, second)
+	})
+	t.Run("should select values without replacement", func(nt *testing.T) {
+		collection := []int{1, 2, 3, 4, 5}
+		sampled, sampledErr := goutils.WeightedSampleSlice(collection, func(val int) float64 {
+			return float64(val)
+		}, 5, rand.New(rand.NewSource(7)))
+		assert.NoError(nt, sampledErr)
+		assert.ElementsMatch(nt, sampled, collection)
+	})
+	t.Run("should select values proportional to weight", func(nt *testing.T) {
+		r := rand.New(rand.NewSource(1))
+		counts := make(map[string]int)
+		for idx := 0; idx < 1000; idx++ {
+			sampled, sampledErr := goutils.WeightedSampleSlice([]string{"heavy", "light", "never"}, func(val string) float64 {
+				switch val {
+				case "heavy":
+					return 9
+				case "light":
+					return 1
+				}
+				return 0
+			}, 1, r)
+			assert.NoError(nt, sampledErr)
+			counts[sampled[0]] += 1
+		}
+		assert.Equal(nt, counts["never"], 0)
+		assert.InDelta(nt, counts["heavy"], 900, 50)
+	})
+	t.Run("should select zero weight values once positive weights are exhausted", func(nt *testing.T) {
+		sampled, sampledErr := goutils.WeightedSampleSlice([]string{"zero", "one"}, func(val string) float64 {
+			if val == "one" {
+				return 1
+			}
+			return 0
+		}, 2, rand.New(rand.NewSource(3)))
+		assert.NoError(nt, sampledErr)
+		assert.Equal(nt, sampled, []string{"one", "zero"})
+	})
+	t.Run("should return error for invalid sample size", func(nt *testing.T) {
+		sampled, sampledErr := goutils.WeightedSampleSlice([]int{1, 2}, func(val int) float64 {
+			return 1
+		}, 3, rand.New(rand.NewSource(1)))
+		assert.ErrorIs(nt, sampledErr, goutils.ErrInvalidSampleSize)
+		assert.Nil(nt, sampled)
+	})
+	t.Run("should return error for negative weight", func(nt *testing.T) {
+		sampled, sampledErr := goutils.WeightedSampleSlice([]int{1, -2}, func(val int) float64 {
+			return float64(val)
+		}, 1, rand.New(rand.NewSource(1)))
+		assert.ErrorIs(nt, sampledErr, goutils.ErrNegativeWeight)
+		assert.Nil(nt, sampled)
+	})
+}
+
+func TestMergeSortedSlices(t *testing.T) {
+	t.Run("should return merged values in sorted order", func(nt *testing.T) {
+		merged := goutils.MergeSortedSlices([]int{1, 4, 9}, []int{2, 3, 10, 11}, []int{}, []int{0, 4, 5})
+		assert.Equal(nt, merged, []int{0, 1, 2, 3, 4, 4, 5, 9, 10, 11})
+	})
+	t.Run("should return same values as sorting concatenated slices", func(nt *testing.T) {
+		slices := make([][]int, 5)
+		expected := make([]int, 0)
+		for sidx := range slices {
+			for idx := 0; idx < rand.Intn(50); idx++ {
+				slices[sidx] = append(slices[sidx], rand.Intn(100))
+			}
+			sort.Ints(slices[sidx])
+			expected = append(expected, slices[sidx]...)
+		}
+		sort.Ints(expected)
+		assert.Equal(nt, goutils.MergeSortedSlices(slices...), expected)
+	})
+	t.Run("should return empty slice when there are no values", func(nt *testing.T) {
+		assert.Equal(nt, goutils.MergeSortedSlices[string](), []string{})
+		assert.Equal(nt, goutils.MergeSortedSlices([]string{}, nil), []string{})
+	})
+}
+
+func TestDedupeConsecutiveSlice(t *testing.T) {
+	t.Run("should collapse runs at start, middle and end", func(nt *testing.T) {
+		assert.Equal(nt, goutils.DedupeConsecutiveSlice([]int{1, 1, 1, 2, 3, 3, 2, 4, 4}), []int{1, 2, 3, 2, 4})
+	})
+	t.Run("should keep slice without runs", func(nt *testing.T) {
+		assert.Equal(nt, goutils.DedupeConsecutiveSlice([]string{"a", "b", "a"}), []string{"a", "b", "a"})
+	})
+	t.Run("should return empty slice for empty input", func(nt *testing.T) {
+		assert.Equal(nt, goutils.DedupeConsecutiveSlice([]int{}), []int{})
+	})
+}
+
+func TestDedupeConsecutiveBySlice(t *testing.T) {
+	t.Run("should collapse runs by key keeping first value", func(nt *testing.T) {
+		collection := []string{"apple", "avocado", "banana", "blueberry", "cherry", "apricot"}
+		assert.Equal(nt, goutils.DedupeConsecutiveBySlice(collection, func(value string) byte {
+			return value[0]
+		}), []string{"apple", "banana", "cherry", "apricot"})
+	})
+}
+
+func TestSearchSortedSlice(t *testing.T) {
+	type user struct {
+		id   int
+		name string
+	}
+	collection := []user{{2, "b"}, {4, "d"}, {4, "e"}, {7, "g"}, {9, "i"}}
+	keyFn := func(value user) int { return value.id }
+	t.Run("should find present target", func(nt *testing.T) {
+		idx, found := goutils.SearchSortedSlice(collection, 7, keyFn)
+		assert.True(nt, found)
+		assert.Equal(nt, idx, 3)
+	})
+	t.Run("should return first of equal keys", func(nt *testing.T) {
+		idx, found := goutils.SearchSortedSlice(collection, 4, keyFn)
+		assert.True(nt, found)
+		assert.Equal(nt, idx, 1)
+	})
+	t.Run("should return insertion index for absent target", func(nt *testing.T) {
+		idx, found := goutils.SearchSortedSlice(collection, 5, keyFn)
+		assert.False(nt, found)
+		assert.Equal(nt, idx, 3)
+	})
+	t.Run("should handle boundary targets", func(nt *testing.T) {
+		idx, found := goutils.SearchSortedSlice(collection, 2, keyFn)
+		assert.True(nt, found)
+		assert.Equal(nt, idx, 0)
+		idx, found = goutils.SearchSortedSlice(collection, 9, keyFn)
+		assert.True(nt, found)
+		assert.Equal(nt, idx, 4)
+		idx, found = goutils.SearchSortedSlice(collection, 1, keyFn)
+		assert.False(nt, found)
+		assert.Equal(nt, idx, 0)
+		idx, found = goutils.SearchSortedSlice(collection, 10, keyFn)
+		assert.False(nt, found)
+		assert.Equal(nt, idx, 5)
+	})
+	t.Run("should return zero for empty slice", func(nt *testing.T) {
+		idx, found := goutils.SearchSortedSlice([]user{}, 3, keyFn)
+		assert.False(nt, found)
+		assert.Equal(nt, idx, 0)
+	})
+}
@@ -0,0 +1,37 @@
+package goutils
+
+import (
+	"fmt"
+)
+
+// Validate checks value against every rule and returns a MultiError of all failures,
+// rather than stopping at the first one. It returns nil if every rule passes.
+func Validate[A any](value A, rules ...func(A) error) error {
+	var errs MultiError
+	for _, rule := range rules {
+		if err := rule(value); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// ValidateSlice checks every value in slice against every rule and returns a MultiError of all failures,
+// each prefixed with the index of the failing value as "index <idx>: <error>". It returns nil if every value is valid.
+func ValidateSlice[A any](collection []A, rules ...func(A) error) error {
+	var errs MultiError
+	for idx, value := range collection {
+		for _, rule := range rules {
+			if err := rule(value); err != nil {
+				errs = append(errs, fmt.Errorf("index %d: %w", idx, err))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package goutils_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/skatiyar/goutils"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	errTooShort = errors.New("too short")
+	errNoDigit  = errors.New("no digit")
+)
+
+func minLength(value string) error {
+	if len(value) < 4 {
+		return errTooShort
+	}
+	return nil
+}
+
+func hasDigit(value string) error {
+	for _, r := range value {
+		if r >= '0' && r <= '9' {
+			return nil
+		}
+	}
+	return errNoDigit
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("should return nil when all rules pass", func(nt *testing.T) {
+		assert.NoError(nt, goutils.Validate("pass1", minLength, hasDigit))
+	})
+	t.Run("should report all failing rules", func(nt *testing.T) {
+		err := goutils.Validate("abc", minLength, hasDigit)
+		assert.EqualError(nt, err, "too short; no digit")
+		assert.ErrorIs(nt, err, errTooShort)
+		assert.ErrorIs(nt, err, errNoDigit)
+		var multiErr goutils.MultiError
+		assert.True(nt, errors.As(err, &multiErr))
+		assert.Equal(nt, multiErr.Unwrap(), []error{errTooShort, errNoDigit})
+	})
+	t.Run("should return nil without rules", func(nt *testing.T) {
+		assert.NoError(nt, goutils.Validate("abc"))
+	})
+}
+
+func TestValidateSlice(t *testing.T) {
+	t.Run("should return nil when all values are valid", func(nt *testing.T) {
+		assert.NoError(nt, goutils.ValidateSlice([]string{"pass1", "pass2"}, minLength, hasDigit))
+	})
+	t.Run("should report errors per index", func(nt *testing.T) {
+		err := goutils.ValidateSlice([]string{"pass1", "abc", "password"}, minLength, hasDigit)
+		assert.EqualError(nt, err, "index 1: too short; index 1: no digit; index 2: no digit")
+		assert.ErrorIs(nt, err, errTooShort)
+		assert.ErrorIs(nt, err, errNoDigit)
+		var multiErr goutils.MultiError
+		assert.True(nt, errors.As(err, &multiErr))
+		assert.ErrorIs(nt, multiErr[0], errTooShort)
+		assert.ErrorIs(nt, multiErr[2], errNoDigit)
+	})
+}